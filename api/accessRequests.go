@@ -0,0 +1,251 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AccessRequests represents SharePoint web access requests API (REST helpers over the Access Requests list)
+// Experimental: SharePoint has no documented API for access requests, Approve and Decline write the Status
+// column of the Access Requests list directly, the status values and the write behavior can change
+// Always use NewAccessRequests constructor instead of &AccessRequests{}
+type AccessRequests struct {
+	web *Web
+}
+
+// AccessRequestSettings web access request configuration
+type AccessRequestSettings struct {
+	RequestAccessEmail      string // email the access requests are sent to, empty disables access requests
+	UseAccessRequestDefault bool   // send the access requests to the site owners instead of RequestAccessEmail (SPO)
+}
+
+// AccessRequestStatuses - access request statuses enumerator, Status field values of the Access Requests list
+// Experimental: the values are not documented by Microsoft
+var AccessRequestStatuses = struct {
+	Pending   int
+	Approved  int
+	Declined  int
+	Accepted  int
+	Expired   int
+	Withdrawn int
+	Revoked   int
+}{
+	Pending:   0,
+	Approved:  1,
+	Declined:  2,
+	Accepted:  3,
+	Expired:   4,
+	Withdrawn: 5,
+	Revoked:   6,
+}
+
+// AccessRequest access request item of the Access Requests list
+type AccessRequest struct {
+	ID                       int    `json:"Id"`
+	Title                    string `json:"Title"`
+	RequestedFor             string `json:"RequestedFor"`             // requester login name or email
+	RequestedBy              string `json:"RequestedBy"`              // user who submitted the request
+	RequestedObjectTitle     string `json:"RequestedObjectTitle"`     // title of the requested web, list or item
+	RequestedObjectURL       string `json:"RequestedObjectUrl"`       // URL of the requested web, list or item
+	PermissionLevelRequested int    `json:"PermissionLevelRequested"` // requested role definition ID
+	Status                   int    `json:"Status"`                   // see AccessRequestStatuses
+	RequestDate              string `json:"RequestDate"`              // time.Time
+	Expires                  string `json:"Expires"`                  // time.Time
+	IsInvitation             bool   `json:"IsInvitation"`             // the item is a sharing invitation rather than a request
+}
+
+// AccessRequestApproval access request approval settings, the requester is added to the group
+// or is granted with the role definition on the web, the web's members group is used when both are empty
+type AccessRequestApproval struct {
+	GroupID   int // SharePoint group ID to add the requester to
+	RoleDefID int // role definition ID to grant on the web, the web should have unique permissions
+}
+
+// AccessRequestStatusError is returned by Approve when access was granted to the requester
+// but the request status could not be updated, the request stays pending in the Access Requests list
+type AccessRequestStatusError struct {
+	RequestID int   // access request item ID
+	Status    int   // status which was not applied, see AccessRequestStatuses
+	Err       error // underlying error
+}
+
+// Error implements error interface
+func (e *AccessRequestStatusError) Error() string {
+	return fmt.Sprintf("access for request %d was granted but its status was not updated to %d: %s", e.RequestID, e.Status, e.Err)
+}
+
+// Unwrap gets the underlying error
+func (e *AccessRequestStatusError) Unwrap() error {
+	return e.Err
+}
+
+// accessRequestFields are Access Requests list fields mapped to AccessRequest
+const accessRequestFields = "Id,Title,RequestedFor,RequestedBy,RequestedObjectTitle,RequestedObjectUrl," +
+	"PermissionLevelRequested,Status,RequestDate,Expires,IsInvitation"
+
+// NewAccessRequests - AccessRequests struct constructor function
+func NewAccessRequests(web *Web) *AccessRequests {
+	return &AccessRequests{web: web}
+}
+
+// AccessRequests gets Access Requests API instance object for this web
+func (web *Web) AccessRequests() *AccessRequests {
+	return NewAccessRequests(web)
+}
+
+// AccessRequestSettings gets the web access request configuration
+func (web *Web) AccessRequestSettings() (*AccessRequestSettings, error) {
+	data, err := NewWeb(web.client, web.endpoint, web.config).Select("RequestAccessEmail,UseAccessRequestDefault").Get()
+	if err != nil {
+		return nil, err
+	}
+	settings := &AccessRequestSettings{}
+	if err := json.Unmarshal(data.Normalized(), &settings); err != nil {
+		return nil, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	return settings, nil
+}
+
+// SetAccessRequestSettings updates the web access request configuration
+func (web *Web) SetAccessRequestSettings(settings *AccessRequestSettings) error {
+	body, _ := json.Marshal(map[string]interface{}{
+		"RequestAccessEmail":      settings.RequestAccessEmail,
+		"UseAccessRequestDefault": settings.UseAccessRequestDefault,
+	})
+	_, err := web.Update(body)
+	return err
+}
+
+// List gets the Access Requests list API instance object, the list exists only after the first request was submitted
+func (requests *AccessRequests) List() (*List, error) {
+	data, err := NewWeb(requests.web.client, requests.web.endpoint, requests.web.config).Select("ServerRelativeUrl").Get()
+	if err != nil {
+		return nil, err
+	}
+	return requests.web.GetList(data.Data().ServerRelativeURL + "/Access%20Requests"), nil
+}
+
+// Get gets access requests with the status, see AccessRequestStatuses, negative status gets all the requests
+func (requests *AccessRequests) Get(status int) ([]*AccessRequest, error) {
+	list, err := requests.List()
+	if err != nil {
+		return nil, err
+	}
+	items := list.Items().Select(accessRequestFields).Top(5000)
+	if status >= 0 {
+		items = items.Filter(fmt.Sprintf("Status eq %d", status))
+	}
+	var res []*AccessRequest
+	pager := items.Pager()
+	for pager.Next() {
+		page := pager.Page()
+		for _, item := range page.Data() {
+			request := &AccessRequest{}
+			if err := json.Unmarshal(item.Normalized(), &request); err != nil {
+				return nil, fmt.Errorf("unable to parse the response: %w", err)
+			}
+			res = append(res, request)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Pending gets pending access requests
+func (requests *AccessRequests) Pending() ([]*AccessRequest, error) {
+	return requests.Get(AccessRequestStatuses.Pending)
+}
+
+// GetByID gets access request by its item ID
+func (requests *AccessRequests) GetByID(requestID int) (*AccessRequest, error) {
+	list, err := requests.List()
+	if err != nil {
+		return nil, err
+	}
+	data, err := list.Items().GetByID(requestID).Select(accessRequestFields).Get()
+	if err != nil {
+		return nil, err
+	}
+	request := &AccessRequest{}
+	if err := json.Unmarshal(data.Normalized(), &request); err != nil {
+		return nil, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	return request, nil
+}
+
+// Approve grants the requester with access due to the approval settings and marks the request as approved,
+// *AccessRequestStatusError is returned when access was granted but the request status was not updated
+// Experimental: see AccessRequests
+func (requests *AccessRequests) Approve(requestID int, approval *AccessRequestApproval) error {
+	if approval == nil {
+		approval = &AccessRequestApproval{}
+	}
+	request, err := requests.GetByID(requestID)
+	if err != nil {
+		return err
+	}
+	if request.Status != AccessRequestStatuses.Pending {
+		return fmt.Errorf("access request %d is not pending, status %d", requestID, request.Status)
+	}
+
+	web := requests.web
+	user, err := web.EnsureUser(request.RequestedFor)
+	if err != nil {
+		return fmt.Errorf("unable to resolve requester %s: %w", request.RequestedFor, err)
+	}
+	switch {
+	case approval.GroupID != 0:
+		err = web.SiteGroups().GetByID(approval.GroupID).AddUser(user.LoginName)
+	case approval.RoleDefID != 0:
+		err = web.Roles().AddAssigment(user.ID, approval.RoleDefID)
+	default:
+		var members GroupResp
+		if members, err = web.AssociatedGroups().Members().Select("Id").Get(); err == nil {
+			err = web.SiteGroups().GetByID(members.Data().ID).AddUser(user.LoginName)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("unable to grant access to %s: %w", request.RequestedFor, err)
+	}
+
+	if err := requests.setStatus(requestID, AccessRequestStatuses.Approved); err != nil {
+		return &AccessRequestStatusError{RequestID: requestID, Status: AccessRequestStatuses.Approved, Err: err}
+	}
+	return nil
+}
+
+// Decline marks the pending request as declined without granting access
+// Experimental: see AccessRequests
+func (requests *AccessRequests) Decline(requestID int) error {
+	request, err := requests.GetByID(requestID)
+	if err != nil {
+		return err
+	}
+	if request.Status != AccessRequestStatuses.Pending {
+		return fmt.Errorf("access request %d is not pending, status %d", requestID, request.Status)
+	}
+	return requests.setStatus(requestID, AccessRequestStatuses.Declined)
+}
+
+// setStatus updates the access request item status, the status is read back
+// as the list can silently ignore the write
+func (requests *AccessRequests) setStatus(requestID int, status int) error {
+	list, err := requests.List()
+	if err != nil {
+		return err
+	}
+	body, _ := json.Marshal(map[string]interface{}{"Status": status})
+	if _, err := list.Items().GetByID(requestID).Update(body); err != nil {
+		return err
+	}
+	request, err := requests.GetByID(requestID)
+	if err != nil {
+		return err
+	}
+	if request.Status != status {
+		return fmt.Errorf("status %d was not applied, the request has status %d", status, request.Status)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAccessRequests(t *testing.T) {
+
+	t.Run("StatusError", func(t *testing.T) {
+		cause := fmt.Errorf("access denied")
+		var err error = &AccessRequestStatusError{RequestID: 1, Status: AccessRequestStatuses.Approved, Err: cause}
+		var statusErr *AccessRequestStatusError
+		if !errors.As(err, &statusErr) || statusErr.RequestID != 1 {
+			t.Error("can't get status error")
+		}
+		if !errors.Is(err, cause) {
+			t.Error("can't unwrap status error cause")
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+
+	t.Run("Settings", func(t *testing.T) {
+		settings, err := web.AccessRequestSettings()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := web.SetAccessRequestSettings(settings); err != nil {
+			t.Error(err)
+		}
+		updated, err := web.AccessRequestSettings()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *updated != *settings {
+			t.Errorf("settings were changed: %+v", updated)
+		}
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		list, err := web.AccessRequests().List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := list.Select("Id").Get(); err != nil {
+			t.Skip("no access requests list in the web")
+		}
+		requests, err := web.AccessRequests().Pending()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range requests {
+			if r.Status != AccessRequestStatuses.Pending {
+				t.Errorf("request %d is not pending", r.ID)
+			}
+		}
+	})
+
+	t.Run("DeclineUnknown", func(t *testing.T) {
+		if err := web.AccessRequests().Decline(-1); err == nil {
+			t.Error("declining unknown request should have failed")
+		}
+	})
+}
@@ -58,10 +58,15 @@ func NewAttachment(client *gosip.SPClient, endpoint string, config *RequestConfi
 	}
 }
 
+// ToURL gets endpoint with modificators raw URL
+func (attachments *Attachments) ToURL() string {
+	return attachments.endpoint
+}
+
 // Get gets attachments collection response
 func (attachments *Attachments) Get() (AttachmentsResp, error) {
 	client := NewHTTPClient(attachments.client)
-	return client.Get(attachments.endpoint, attachments.config)
+	return client.Get(attachments.ToURL(), attachments.config)
 }
 
 // Add uploads new attachment to the item
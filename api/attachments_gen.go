@@ -19,3 +19,36 @@ func (attachmentsResp *AttachmentsResp) Normalized() []byte {
 	normalized, _ := NormalizeODataCollection(*attachmentsResp)
 	return normalized
 }
+
+/* Pagination helpers */
+
+// AttachmentsPager - Attachments collection pages iterator
+type AttachmentsPager struct {
+	*Pager
+}
+
+// Pager gets Attachments collection pages iterator, the iteration follows OData next page links
+func (attachments *Attachments) Pager() *AttachmentsPager {
+	return &AttachmentsPager{NewPager(attachments.client, attachments.ToURL(), attachments.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *AttachmentsPager) Prefetch(enabled bool) *AttachmentsPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *AttachmentsPager) Page() AttachmentsResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (attachmentsResp *AttachmentsResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*attachmentsResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (attachmentsResp *AttachmentsResp) HasNextPage() bool {
+	return attachmentsResp.NextPageURL() != ""
+}
@@ -108,6 +108,70 @@ func (changes *Changes) GetCurrentToken() (string, error) {
 
 // GetChanges gets changes in scope of the parent container using provided change query
 func (changes *Changes) GetChanges(changeQuery *ChangeQuery) (*ChangesResp, error) {
+	data, err := changes.getChanges(changeQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ChangesResp{}
+	result.Data = func() []*ChangeInfo {
+		result.data = parseChanges(data)
+		return result.data
+	}
+	result.GetNextPage = func() (*ChangesResp, error) {
+		if result.data == nil {
+			result.Data()
+		}
+		if len(result.data) == 0 {
+			return nil, fmt.Errorf("can't get next page of an empty collection")
+		}
+		changeQuery.ChangeTokenStart = result.data[len(result.data)-1].ChangeToken.StringValue
+		return changes.GetChanges(changeQuery)
+	}
+
+	return result, nil
+}
+
+// ChangesPager - changes collection pages iterator
+type ChangesPager struct {
+	*Pager
+}
+
+// Pager gets changes pages iterator, each next page starts from the last change token of the previous one.
+// The iteration stops on an empty page, use Top modifier to control the page size.
+func (changes *Changes) Pager(changeQuery *ChangeQuery) *ChangesPager {
+	query := ChangeQuery{}
+	if changeQuery != nil {
+		query = *changeQuery
+	}
+	pager := newPager(query.ChangeTokenStart, changes.config, func(token string) ([]byte, string, error) {
+		query.ChangeTokenStart = token
+		data, err := changes.getChanges(&query)
+		if err != nil {
+			return nil, "", err
+		}
+		next := ""
+		if c := parseChanges(data); len(c) > 0 && c[len(c)-1].ChangeToken != nil {
+			next = c[len(c)-1].ChangeToken.StringValue
+		}
+		return data, next, nil
+	})
+	return &ChangesPager{pager}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *ChangesPager) Prefetch(enabled bool) *ChangesPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page changes
+func (pager *ChangesPager) Page() []*ChangeInfo {
+	return parseChanges(pager.Pager.Page())
+}
+
+// getChanges requests changes collection raw response
+func (changes *Changes) getChanges(changeQuery *ChangeQuery) ([]byte, error) {
 	endpoint := toURL(fmt.Sprintf("%s/GetChanges", changes.endpoint), changes.modifiers)
 	client := NewHTTPClient(changes.client)
 	metadata := map[string]interface{}{}
@@ -132,37 +196,20 @@ func (changes *Changes) GetChanges(changeQuery *ChangeQuery) (*ChangesResp, erro
 	if err != nil {
 		return nil, err
 	}
-	data, err := client.Post(endpoint, bytes.NewBuffer(body), changes.config)
-	if err != nil {
-		return nil, err
-	}
+	return client.Post(endpoint, bytes.NewBuffer(body), changes.config)
+}
 
-	result := &ChangesResp{}
-	result.Data = func() []*ChangeInfo {
-		result.data = nil
-		collection, _ := normalizeODataCollection(data)
-		var changesInfo []*ChangeInfo
-		for _, changeItem := range collection {
-			c := &ChangeInfo{}
-			if err := json.Unmarshal(changeItem, &c); err == nil {
-				changesInfo = append(changesInfo, c)
-			}
+// parseChanges parses changes collection response
+func parseChanges(data []byte) []*ChangeInfo {
+	collection, _ := normalizeODataCollection(data)
+	var changesInfo []*ChangeInfo
+	for _, changeItem := range collection {
+		c := &ChangeInfo{}
+		if err := json.Unmarshal(changeItem, &c); err == nil {
+			changesInfo = append(changesInfo, c)
 		}
-		result.data = changesInfo
-		return changesInfo
 	}
-	result.GetNextPage = func() (*ChangesResp, error) {
-		if result.data == nil {
-			result.Data()
-		}
-		if len(result.data) == 0 {
-			return nil, fmt.Errorf("can't get next page of an empty collection")
-		}
-		changeQuery.ChangeTokenStart = result.data[len(result.data)-1].ChangeToken.StringValue
-		return changes.GetChanges(changeQuery)
-	}
-
-	return result, nil
+	return changesInfo
 }
 
 // GetChangeType gets verbose change type
@@ -7,7 +7,7 @@ import (
 	"github.com/pnocera/gosip"
 )
 
-//go:generate ggen -ent ContentType -conf -mods Select,Expand -helpers Data,Normalized
+//go:generate ggen -ent ContentType -conf -etag -mods Select,Expand -helpers Data,Normalized
 
 // ContentType represents SharePoint Content Types API queryable object struct
 // Always use NewContentType constructor instead of &ContentType{}
@@ -1,4 +1,4 @@
-// Code generated by `ggen -ent ContentType -conf -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
+// Code generated by `ggen -ent ContentType -conf -etag -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
 
 package api
 
@@ -38,3 +38,18 @@ func (contentTypeResp *ContentTypeResp) Data() *ContentTypeInfo {
 func (contentTypeResp *ContentTypeResp) Normalized() []byte {
 	return NormalizeODataItem(*contentTypeResp)
 }
+
+/* Optimistic concurrency helpers */
+
+// IfMatch gets a copy of the entity with the expected ETag for Update and Delete requests,
+// the requests fail with *PreconditionFailedError when the entity was modified since the ETag was received
+func (contentType *ContentType) IfMatch(etag string) *ContentType {
+	scoped := *contentType
+	scoped.config = withIfMatch(contentType.config, etag)
+	return &scoped
+}
+
+// ETag gets entity ETag from the response metadata
+func (contentTypeResp *ContentTypeResp) ETag() string {
+	return getODataETag(*contentTypeResp)
+}
@@ -55,3 +55,36 @@ func (contentTypesResp *ContentTypesResp) Normalized() []byte {
 	normalized, _ := NormalizeODataCollection(*contentTypesResp)
 	return normalized
 }
+
+/* Pagination helpers */
+
+// ContentTypesPager - ContentTypes collection pages iterator
+type ContentTypesPager struct {
+	*Pager
+}
+
+// Pager gets ContentTypes collection pages iterator, the iteration follows OData next page links
+func (contentTypes *ContentTypes) Pager() *ContentTypesPager {
+	return &ContentTypesPager{NewPager(contentTypes.client, contentTypes.ToURL(), contentTypes.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *ContentTypesPager) Prefetch(enabled bool) *ContentTypesPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *ContentTypesPager) Page() ContentTypesResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (contentTypesResp *ContentTypesResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*contentTypesResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (contentTypesResp *ContentTypesResp) HasNextPage() bool {
+	return contentTypesResp.NextPageURL() != ""
+}
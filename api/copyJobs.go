@@ -0,0 +1,218 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CopyJobOptions provides optional settings for CreateCopyJobs method
+type CopyJobOptions struct {
+	IsMoveMode           bool // move objects instead of copying
+	KeepBoth             bool // keep both objects on a name conflict renaming the copied one, the job fails on a conflict otherwise
+	IgnoreVersionHistory bool // copy only the current version
+	AllowSchemaMismatch  bool // allow copying to a library with different fields
+	ExcludeChildren      bool // copy folders without their content
+}
+
+// CopyJobInfo describes created copy job, is required to get the job progress
+type CopyJobInfo struct {
+	EncryptionKey string `json:"EncryptionKey"` // base64 encoded AES key of the job queue messages
+	JobID         string `json:"JobId"`
+	JobQueueURI   string `json:"JobQueueUri"`
+}
+
+// CopyJobProgress describes copy job progress
+type CopyJobProgress struct {
+	JobState int           // 0 - completed, 2 - processing, 4 - queued
+	Logs     []*CopyJobLog // job events since the previous progress request
+}
+
+// CopyJobLog describes copy job event, GetCopyJobProgress returns decrypted queue messages
+type CopyJobLog struct {
+	Event               string `json:"Event"` // e.g. JobQueued, JobStart, JobLogFileCreate, JobEnd, JobError, JobFatalError
+	JobID               string `json:"JobId"`
+	Time                string `json:"Time"`
+	SourceObjectFullURL string `json:"SourceObjectFullUrl"`
+	TargetObjectFullURL string `json:"TargetObjectFullUrl"`
+	ObjectType          string `json:"ObjectType"`
+	Message             string `json:"Message"`
+	ErrorCode           string `json:"ErrorCode"`
+	ErrorType           string `json:"ErrorType"`
+	TotalErrors         string `json:"TotalErrors"`
+	CorrelationID       string `json:"CorrelationId"`
+}
+
+// CopyJob is a copy job handle
+type CopyJob struct {
+	Info *CopyJobInfo
+	site *Site
+	logs []*CopyJobLog
+}
+
+// CreateCopyJobs creates asynchronous jobs copying or moving files and folders to a destination folder,
+// across sites of the same tenant. Source and destination are absolute, server relative or this site relative URLs.
+// Supported only in SharePoint Online.
+func (site *Site) CreateCopyJobs(sourceURLs []string, destinationURL string, options *CopyJobOptions) ([]*CopyJob, error) {
+	if options == nil {
+		options = &CopyJobOptions{}
+	}
+	webURL := getPriorEndpoint(site.endpoint, "/_api")
+	var exportURIs []string
+	for _, u := range sourceURLs {
+		exportURIs = append(exportURIs, toAbsoluteURL(webURL, u))
+	}
+	nameConflictBehavior := 0 // Fail
+	if options.KeepBoth {
+		nameConflictBehavior = 2 // Rename
+	}
+	payload := map[string]interface{}{
+		"exportObjectUris": exportURIs,
+		"destinationUri":   toAbsoluteURL(webURL, destinationURL),
+		"options": map[string]interface{}{
+			"__metadata":           map[string]string{"type": "SP.CopyMigrationOptions"},
+			"IsMoveMode":           options.IsMoveMode,
+			"IgnoreVersionHistory": options.IgnoreVersionHistory,
+			"AllowSchemaMismatch":  options.AllowSchemaMismatch,
+			"ExcludeChildren":      options.ExcludeChildren,
+			"NameConflictBehavior": nameConflictBehavior,
+		},
+	}
+	body, _ := json.Marshal(payload)
+
+	client := NewHTTPClient(site.client)
+	endpoint := fmt.Sprintf("%s/CreateCopyJobs", site.endpoint)
+	data, err := client.Post(endpoint, bytes.NewBuffer(body), patchConfigHeaders(site.config, HeadersPresets.Verbose.Headers))
+	if err != nil {
+		return nil, err
+	}
+
+	r := &struct {
+		D struct {
+			CreateCopyJobs struct {
+				Results []*CopyJobInfo `json:"results"`
+			} `json:"CreateCopyJobs"`
+		} `json:"d"`
+	}{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	var jobs []*CopyJob
+	for _, info := range r.D.CreateCopyJobs.Results {
+		jobs = append(jobs, &CopyJob{Info: info, site: site})
+	}
+	return jobs, nil
+}
+
+// GetCopyJobProgress gets copy job state and events since the previous request
+func (site *Site) GetCopyJobProgress(info *CopyJobInfo) (*CopyJobProgress, error) {
+	payload := map[string]interface{}{
+		"copyJobInfo": map[string]interface{}{
+			"__metadata":    map[string]string{"type": "SP.CopyMigrationInfo"},
+			"EncryptionKey": info.EncryptionKey,
+			"JobId":         info.JobID,
+			"JobQueueUri":   info.JobQueueURI,
+		},
+	}
+	body, _ := json.Marshal(payload)
+
+	client := NewHTTPClient(site.client)
+	endpoint := fmt.Sprintf("%s/GetCopyJobProgress", site.endpoint)
+	data, err := client.Post(endpoint, bytes.NewBuffer(body), patchConfigHeaders(site.config, HeadersPresets.Verbose.Headers))
+	if err != nil {
+		return nil, err
+	}
+
+	r := &struct {
+		D struct {
+			GetCopyJobProgress struct {
+				JobState int `json:"JobState"`
+				Logs     struct {
+					Results []string `json:"results"`
+				} `json:"Logs"`
+			} `json:"GetCopyJobProgress"`
+		} `json:"d"`
+	}{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	progress := &CopyJobProgress{JobState: r.D.GetCopyJobProgress.JobState}
+	for _, l := range r.D.GetCopyJobProgress.Logs.Results {
+		log := &CopyJobLog{}
+		if err := json.Unmarshal([]byte(l), &log); err != nil {
+			log.Message = l
+		}
+		progress.Logs = append(progress.Logs, log)
+	}
+	return progress, nil
+}
+
+// Progress gets the job progress, the job keeps all received events in Logs
+func (job *CopyJob) Progress() (*CopyJobProgress, error) {
+	progress, err := job.site.GetCopyJobProgress(job.Info)
+	if err != nil {
+		return nil, err
+	}
+	job.logs = append(job.logs, progress.Logs...)
+	return progress, nil
+}
+
+// Logs gets all events received with Progress and Wait methods
+func (job *CopyJob) Logs() []*CopyJobLog {
+	return job.logs
+}
+
+// Wait polls the job progress with the interval until the job is completed,
+// returns an error when the job reported errors, the context of the site's RequestConfig cancels waiting
+func (job *CopyJob) Wait(interval time.Duration) error {
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	var done <-chan struct{}
+	if job.site.config != nil && job.site.config.Context != nil {
+		done = job.site.config.Context.Done()
+	}
+	for {
+		progress, err := job.Progress()
+		if err != nil {
+			return err
+		}
+		if progress.JobState == 0 {
+			break
+		}
+		select {
+		case <-done:
+			return job.site.config.Context.Err()
+		case <-time.After(interval):
+		}
+	}
+	var errs []string
+	for _, log := range job.logs {
+		if log.Event == "JobError" || log.Event == "JobFatalError" {
+			errs = append(errs, strings.TrimSpace(log.ErrorCode+" "+log.Message))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("copy job %s failed: %s", job.Info.JobID, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// toAbsoluteURL converts server relative URL to absolute URL using the web URL host,
+// web relative URLs (without leading slash, e.g. `Shared Documents/folder`) are resolved against the web URL
+func toAbsoluteURL(webURL string, relativeURL string) string {
+	if strings.HasPrefix(strings.ToLower(relativeURL), "http://") || strings.HasPrefix(strings.ToLower(relativeURL), "https://") {
+		return relativeURL
+	}
+	u, err := url.Parse(webURL)
+	if err != nil {
+		return relativeURL
+	}
+	if !strings.HasPrefix(relativeURL, "/") {
+		relativeURL = strings.TrimRight(u.Path, "/") + "/" + relativeURL
+	}
+	return fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, relativeURL)
+}
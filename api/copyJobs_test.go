@@ -0,0 +1,90 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCopyJobs(t *testing.T) {
+
+	t.Run("toAbsoluteURL", func(t *testing.T) {
+		siteURL := "https://contoso.sharepoint.com/sites/site"
+		if u := toAbsoluteURL(siteURL, "/sites/other/Docs/a#b%c"); u != "https://contoso.sharepoint.com/sites/other/Docs/a#b%c" {
+			t.Errorf("incorrect absolute URL: %s", u)
+		}
+		if u := toAbsoluteURL(siteURL, "https://contoso.sharepoint.com/sites/other"); u != "https://contoso.sharepoint.com/sites/other" {
+			t.Errorf("absolute URL should not be changed: %s", u)
+		}
+		for _, webURL := range []string{siteURL, siteURL + "/"} {
+			if u := toAbsoluteURL(webURL, "Shared Documents/a#b"); u != "https://contoso.sharepoint.com/sites/site/Shared Documents/a#b" {
+				t.Errorf("incorrect absolute URL of web relative one: %s", u)
+			}
+		}
+	})
+
+	checkClient(t)
+
+	if envCode != "spo" {
+		t.Skip("is supported only in SPO")
+	}
+
+	web := NewSP(spClient).Web()
+	rootFolderURI := getRelativeURL(spClient.AuthCnfg.GetSiteURL()) + "/Shared Documents"
+	srcFolderURI := rootFolderURI + "/" + uuid.New().String()
+	dstFolderURI := rootFolderURI + "/" + uuid.New().String()
+	for _, u := range []string{srcFolderURI, dstFolderURI} {
+		if _, err := web.EnsureFolder(u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := web.GetFolder(srcFolderURI).Files().Add("file.txt", []byte("content"), true); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("CreateCopyJobs", func(t *testing.T) {
+		jobs, err := NewSP(spClient).Site().CreateCopyJobs([]string{srcFolderURI + "/file.txt"}, dstFolderURI, &CopyJobOptions{KeepBoth: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs) != 1 || jobs[0].Info.JobID == "" {
+			t.Fatal("incorrect copy jobs")
+		}
+		if err := jobs[0].Wait(time.Second); err != nil {
+			t.Error(err)
+		}
+		if len(jobs[0].Logs()) == 0 {
+			t.Error("no job logs received")
+		}
+	})
+
+	t.Run("FolderCopyToUsingPath", func(t *testing.T) {
+		if _, err := web.GetFolder(dstFolderURI).CopyToUsingPath(srcFolderURI+"/Copy #2 %", true); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("FolderMoveToUsingPath", func(t *testing.T) {
+		folder := web.GetFolderByPath(escapeResourcePath(srcFolderURI + "/Copy #2 %"))
+		if _, err := folder.MoveToUsingPath(srcFolderURI+"/Moved #2 %", true); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("FolderCopyToWithOptions", func(t *testing.T) {
+		if err := web.GetFolder(srcFolderURI).CopyToWithOptions(dstFolderURI+"/Copy #1 %", nil); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("FolderMoveToWithOptions", func(t *testing.T) {
+		if err := web.GetFolder(dstFolderURI).MoveToWithOptions(srcFolderURI+"/Moved", nil); err != nil {
+			t.Error(err)
+		}
+	})
+
+	if err := web.GetFolder(srcFolderURI).Delete(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,363 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pnocera/gosip/csom"
+)
+
+// documentSetTypeIDs caches DocumentSet CSOM type IDs by site URL
+var documentSetTypeIDs sync.Map
+
+// documentSetTypeIDRegExp matches GetDocumentSet static method declaration in SP.DocumentManagement JSOM library
+var documentSetTypeIDRegExp = regexp.MustCompile(`['"](\{[0-9a-fA-F-]{36}\})['"]\s*,\s*['"]GetDocumentSet['"]`)
+
+// DocumentSets represents SharePoint Document Sets API in a document library (REST helpers)
+// Always use NewDocumentSets constructor instead of &DocumentSets{}
+type DocumentSets struct {
+	list *List
+}
+
+// DocumentSetCreationInfo new document set metadata
+type DocumentSetCreationInfo struct {
+	Name          string            // Document set folder name
+	ContentTypeID string            // Document set content type ID in the library, e.g. 0x0120D520...
+	FolderPath    string            // Decoded server relative URL of the parent folder, optional, the library root folder is used by default
+	Properties    map[string]string // Property form values, see Items.AddValidate for values fingerprints
+}
+
+// DocumentSet represents SharePoint Document Set API (REST+CSOM helpers)
+// Always use NewDocumentSet constructor instead of &DocumentSet{}
+type DocumentSet struct {
+	folder *Folder
+}
+
+// DocumentSetTemplate describes document set content type settings, fields are referenced by IDs
+type DocumentSetTemplate struct {
+	AllowedContentTypes []string // content type IDs allowed in the document set
+	WelcomePageFields   []string // field IDs shown on the welcome page
+	SharedFields        []string // field IDs synchronized from the document set to its documents
+}
+
+// NewDocumentSets - DocumentSets struct constructor function
+func NewDocumentSets(list *List) *DocumentSets {
+	return &DocumentSets{list: list}
+}
+
+// NewDocumentSet - DocumentSet struct constructor function
+func NewDocumentSet(folder *Folder) *DocumentSet {
+	return &DocumentSet{folder: folder}
+}
+
+// DocumentSets gets Document Sets API instance object for this library
+func (list *List) DocumentSets() *DocumentSets {
+	return NewDocumentSets(list)
+}
+
+// DocumentSet gets Document Set API instance object for this folder
+func (folder *Folder) DocumentSet() *DocumentSet {
+	return NewDocumentSet(folder)
+}
+
+// Add creates a document set of the content type with the property values
+func (docSets *DocumentSets) Add(info *DocumentSetCreationInfo) (*DocumentSet, error) {
+	list := docSets.list
+	folderPath := info.FolderPath
+	if folderPath == "" {
+		data, err := list.RootFolder().Select("ServerRelativeUrl").Get()
+		if err != nil {
+			return nil, err
+		}
+		folderPath = data.Data().ServerRelativeURL
+	}
+	folderPath = checkGetRelativeURL(folderPath, list.endpoint)
+
+	type formValue struct {
+		FieldName  string `json:"FieldName"`
+		FieldValue string `json:"FieldValue"`
+	}
+	formValues := []*formValue{
+		{FieldName: "ContentTypeId", FieldValue: info.ContentTypeID},
+		{FieldName: "HTML_x0020_File_x0020_Type", FieldValue: "SharePoint.DocumentSet"},
+	}
+	for n, v := range info.Properties {
+		formValues = append(formValues, &formValue{FieldName: n, FieldValue: v})
+	}
+	payload := map[string]interface{}{
+		"formValues": formValues,
+		"listItemCreateInfo": map[string]interface{}{
+			"__metadata": map[string]string{"type": "SP.ListItemCreationInformationUsingPath"},
+			"FolderPath": map[string]interface{}{
+				"__metadata": map[string]string{"type": "SP.ResourcePath"},
+				"DecodedUrl": folderPath,
+			},
+			"LeafName": map[string]interface{}{
+				"__metadata": map[string]string{"type": "SP.ResourcePath"},
+				"DecodedUrl": info.Name,
+			},
+			"UnderlyingObjectType": 1, // Folder
+		},
+	}
+	body, _ := json.Marshal(payload)
+
+	client := NewHTTPClient(list.client)
+	endpoint := fmt.Sprintf("%s/AddValidateUpdateItemUsingPath()", list.endpoint)
+	res, err := client.Post(endpoint, bytes.NewBuffer(body), list.config)
+	if err != nil {
+		return nil, err
+	}
+	avResp := AddValidateResp(res)
+	var errs []error
+	for _, f := range avResp.Data() {
+		if f.HasException {
+			errs = append(errs, fmt.Errorf("%s: %s", f.FieldName, f.ErrorMessage))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%v", errs)
+	}
+
+	folder := list.ParentWeb().GetFolderByPath(escapeResourcePath(folderPath + "/" + info.Name))
+	return NewDocumentSet(folder), nil
+}
+
+// Get gets document set by its folder decoded server relative URL
+func (docSets *DocumentSets) Get(serverRelativeURL string) *DocumentSet {
+	return NewDocumentSet(docSets.list.ParentWeb().GetFolderByPath(escapeResourcePath(serverRelativeURL)))
+}
+
+// Folder gets document set Folder API instance object
+func (docSet *DocumentSet) Folder() *Folder {
+	return docSet.folder
+}
+
+// Template gets document set content type settings
+func (docSet *DocumentSet) Template() (*DocumentSetTemplate, error) {
+	item, err := docSet.folder.GetItem()
+	if err != nil {
+		return nil, err
+	}
+	itemR, err := item.Select("ContentTypeId").Get()
+	if err != nil {
+		return nil, err
+	}
+	return item.ParentList().ContentTypes().GetByID(itemR.Data().ContentTypeID).DocumentSetTemplate()
+}
+
+// WelcomePageFields gets values of the welcome page fields of this document set mapped by field internal names
+func (docSet *DocumentSet) WelcomePageFields() (map[string]interface{}, error) {
+	template, err := docSet.Template()
+	if err != nil {
+		return nil, err
+	}
+	item, err := docSet.folder.GetItem()
+	if err != nil {
+		return nil, err
+	}
+	fieldsR, err := item.ParentList().Fields().Select("Id,InternalName").Get()
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]string{}
+	for _, f := range fieldsR.Data() {
+		names[normalizeDocumentSetID(f.Data().ID)] = f.Data().InternalName
+	}
+	var selectFields []string
+	for _, id := range template.WelcomePageFields {
+		if name, ok := names[normalizeDocumentSetID(id)]; ok {
+			selectFields = append(selectFields, name)
+		}
+	}
+	values := map[string]interface{}{}
+	if len(selectFields) == 0 {
+		return values, nil
+	}
+	itemR, err := item.Select(strings.Join(selectFields, ",")).Get()
+	if err != nil {
+		return nil, err
+	}
+	all := map[string]interface{}{}
+	if err := json.Unmarshal(NormalizeODataItem(itemR), &all); err != nil {
+		return nil, err
+	}
+	for _, name := range selectFields {
+		values[name] = all[name]
+	}
+	return values, nil
+}
+
+// AddDocuments uploads documents to the document set, `documents` are contents mapped by file names
+func (docSet *DocumentSet) AddDocuments(documents map[string][]byte, overwrite bool) error {
+	var errs []string
+	for name, content := range documents {
+		if _, err := docSet.folder.Files().Add(name, content, overwrite); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("unable to add documents: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// MoveDocuments moves documents into the document set, `fileURLs` are decoded server relative URLs of the files
+func (docSet *DocumentSet) MoveDocuments(fileURLs []string, overwrite bool) error {
+	folderURL, err := docSet.folder.serverRelativeURL()
+	if err != nil {
+		return err
+	}
+	web := NewWeb(docSet.folder.client, getIncludeEndpoint(docSet.folder.endpoint, "/Web"), docSet.folder.config)
+	var errs []string
+	for _, fileURL := range fileURLs {
+		newURL := folderURL + "/" + path.Base(fileURL)
+		if _, err := web.GetFileByPath(escapeResourcePath(fileURL)).MoveToUsingPath(newURL, overwrite); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", fileURL, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("unable to move documents: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// CaptureVersion captures a version of the whole document set with its documents and property values,
+// the list should have versioning enabled (CSOM helper, DocumentSet.VersionCollection.Add)
+func (docSet *DocumentSet) CaptureVersion(comment string, major bool) error {
+	folderURL, err := docSet.folder.serverRelativeURL()
+	if err != nil {
+		return err
+	}
+	typeID, err := docSet.typeID()
+	if err != nil {
+		return err
+	}
+
+	b := csom.NewBuilder()
+	b.AddObject(csom.NewObjectProperty("Web"), nil)
+	b.AddObject(csom.NewObjectMethod("GetFolderByServerRelativeUrl", []string{
+		`<Parameter Type="String">` + html.EscapeString(folderURL) + `</Parameter>`,
+	}), nil)
+	b.AddObject(csom.NewObject(`
+		<StaticMethod Id="{{.ID}}" Name="GetDocumentSet" TypeId="`+typeID+`">
+			<Parameters>
+				<Parameter ObjectPathId="{{.ParentID}}" />
+			</Parameters>
+		</StaticMethod>
+	`), nil)
+	b.AddObject(csom.NewObjectProperty("VersionCollection"), nil)
+	b.AddAction(csom.NewActionMethod("Add", []string{
+		fmt.Sprintf(`<Parameter Type="Boolean">%t</Parameter>`, major),
+		`<Parameter Type="String">` + html.EscapeString(comment) + `</Parameter>`,
+	}), nil)
+
+	csomPkg, err := b.Compile()
+	if err != nil {
+		return err
+	}
+
+	client := NewHTTPClient(docSet.folder.client)
+	_, err = client.ProcessQuery(docSet.folder.client.AuthCnfg.GetSiteURL(), bytes.NewBuffer([]byte(csomPkg)), docSet.folder.config)
+	return err
+}
+
+// typeID gets Microsoft.SharePoint.Client.DocumentSet.DocumentSet CSOM type ID declared
+// in the site's SP.DocumentManagement JSOM library, the ID is cached per site
+func (docSet *DocumentSet) typeID() (string, error) {
+	siteURL := docSet.folder.client.AuthCnfg.GetSiteURL()
+	if typeID, ok := documentSetTypeIDs.Load(siteURL); ok {
+		return typeID.(string), nil
+	}
+	conf := &RequestConfig{Headers: map[string]string{"Accept": "*/*"}}
+	if docSet.folder.config != nil {
+		conf.Context = docSet.folder.config.Context
+	}
+	client := NewHTTPClient(docSet.folder.client)
+	js, err := client.Get(siteURL+"/_layouts/15/sp.documentmanagement.js", conf)
+	if err != nil {
+		return "", fmt.Errorf("unable to get document management library: %w", err)
+	}
+	typeID, err := parseDocumentSetTypeID(js)
+	if err != nil {
+		return "", err
+	}
+	documentSetTypeIDs.Store(siteURL, typeID)
+	return typeID, nil
+}
+
+// parseDocumentSetTypeID gets DocumentSet CSOM type ID from SP.DocumentManagement JSOM library source
+func parseDocumentSetTypeID(js []byte) (string, error) {
+	match := documentSetTypeIDRegExp.FindSubmatch(js)
+	if match == nil {
+		return "", fmt.Errorf("can't find DocumentSet type ID in document management library")
+	}
+	return string(match[1]), nil
+}
+
+// DocumentSetTemplate gets document set settings of this content type, the settings are empty for regular content types
+func (contentType *ContentType) DocumentSetTemplate() (*DocumentSetTemplate, error) {
+	data, err := NewContentType(contentType.client, contentType.endpoint, contentType.config).Select("SchemaXml").Get()
+	if err != nil {
+		return nil, err
+	}
+	return parseDocumentSetTemplate(data.Data().SchemaXML)
+}
+
+// parseDocumentSetTemplate gets document set settings from content type schema XmlDocuments,
+// the documents can be either inline or escaped XML
+func parseDocumentSetTemplate(schemaXML string) (*DocumentSetTemplate, error) {
+	template := &DocumentSetTemplate{}
+	var parse func(doc string) error
+	parse = func(doc string) error {
+		decoder := xml.NewDecoder(strings.NewReader(doc))
+		for {
+			token, err := decoder.Token()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("unable to parse content type schema: %w", err)
+			}
+			switch t := token.(type) {
+			case xml.StartElement:
+				id := ""
+				for _, attr := range t.Attr {
+					if strings.EqualFold(attr.Name.Local, "id") {
+						id = attr.Value
+					}
+				}
+				switch t.Name.Local {
+				case "AllowedContentType":
+					template.AllowedContentTypes = append(template.AllowedContentTypes, id)
+				case "WelcomePageField":
+					template.WelcomePageFields = append(template.WelcomePageFields, id)
+				case "SharedField":
+					template.SharedFields = append(template.SharedFields, id)
+				}
+			case xml.CharData:
+				if text := strings.TrimSpace(string(t)); strings.HasPrefix(text, "<") {
+					if err := parse(text); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	if err := parse(schemaXML); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// normalizeDocumentSetID normalizes GUID for comparison
+func normalizeDocumentSetID(id string) string {
+	return strings.ToLower(strings.Trim(id, "{}"))
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDocumentSet(t *testing.T) {
+
+	t.Run("ParseTemplate", func(t *testing.T) {
+		schemaXML := `<ContentType ID="0x0120D520"><XmlDocuments>` +
+			`<XmlDocument NamespaceURI="http://schemas.microsoft.com/office/documentsets/allowedcontenttypes">` +
+			`<act:AllowedContentTypes xmlns:act="http://schemas.microsoft.com/office/documentsets/allowedcontenttypes" LastModified="1/1/1 0:00:01 AM">` +
+			`<AllowedContentType id="0x0101" /></act:AllowedContentTypes></XmlDocument>` +
+			`<XmlDocument NamespaceURI="http://schemas.microsoft.com/office/documentsets/welcomepagefields">` +
+			`&lt;wpFields:WelcomePageFields xmlns:wpFields="http://schemas.microsoft.com/office/documentsets/welcomepagefields"&gt;` +
+			`&lt;WelcomePageField id="{cbb92da4-fd46-4c7d-af6c-3128c2a5576e}" /&gt;&lt;/wpFields:WelcomePageFields&gt;</XmlDocument>` +
+			`<XmlDocument NamespaceURI="http://schemas.microsoft.com/office/documentsets/sharedfields">` +
+			`<sf:SharedFields xmlns:sf="http://schemas.microsoft.com/office/documentsets/sharedfields" LastModified="1/1/1 0:00:01 AM" />` +
+			`</XmlDocument></XmlDocuments></ContentType>`
+		template, err := parseDocumentSetTemplate(schemaXML)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(template.AllowedContentTypes) != 1 || template.AllowedContentTypes[0] != "0x0101" {
+			t.Errorf("incorrect allowed content types: %v", template.AllowedContentTypes)
+		}
+		if len(template.WelcomePageFields) != 1 || normalizeDocumentSetID(template.WelcomePageFields[0]) != "cbb92da4-fd46-4c7d-af6c-3128c2a5576e" {
+			t.Errorf("incorrect welcome page fields: %v", template.WelcomePageFields)
+		}
+		if len(template.SharedFields) != 0 {
+			t.Errorf("incorrect shared fields: %v", template.SharedFields)
+		}
+	})
+
+	t.Run("ParseTypeID", func(t *testing.T) {
+		debug := []byte(`SP.DocumentSet.DocumentSet.getDocumentSet = function(context, folder) {
+			$v_0 = new SP.DocumentSet.DocumentSet(context, new SP.ObjectPathStaticMethod(context, '{2a6d5e1f-0c4b-4f5a-a6c3-3c2e8e9f5d1a}', 'GetDocumentSet', [ folder ]));`)
+		minified := []byte(`c=new SP.DocumentSet.DocumentSet(a,new SP.ObjectPathStaticMethod(a,"{2a6d5e1f-0c4b-4f5a-a6c3-3c2e8e9f5d1a}","GetDocumentSet",[b]))`)
+		for _, js := range [][]byte{debug, minified} {
+			typeID, err := parseDocumentSetTypeID(js)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if typeID != "{2a6d5e1f-0c4b-4f5a-a6c3-3c2e8e9f5d1a}" {
+				t.Errorf("incorrect type ID: %s", typeID)
+			}
+		}
+		if _, err := parseDocumentSetTypeID([]byte(`SP.DocumentSet.DocumentSet.create = function() {}`)); err == nil {
+			t.Error("missing type ID should have failed")
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	list := web.GetList("Shared Documents")
+
+	ctsR, err := list.ContentTypes().Select("StringId").Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	contentTypeID := ""
+	for _, ct := range ctsR.Data() {
+		if strings.HasPrefix(strings.ToUpper(ct.Data().ID), "0X0120D520") {
+			contentTypeID = ct.Data().ID
+		}
+	}
+	if contentTypeID == "" {
+		t.Skip("document set content type is not added to the library")
+	}
+
+	docSetName := uuid.New().String()
+	docSet, err := list.DocumentSets().Add(&DocumentSetCreationInfo{
+		Name:          docSetName,
+		ContentTypeID: contentTypeID,
+		Properties:    map[string]string{"Title": docSetName},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Template", func(t *testing.T) {
+		template, err := docSet.Template()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(template.AllowedContentTypes) == 0 {
+			t.Error("allowed content types are empty")
+		}
+		if _, err := docSet.WelcomePageFields(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("AddDocuments", func(t *testing.T) {
+		if err := docSet.AddDocuments(map[string][]byte{"doc1.txt": []byte("doc1"), "doc2.txt": []byte("doc2")}, true); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("CaptureVersion", func(t *testing.T) {
+		if err := docSet.CaptureVersion("Gosip", true); err != nil {
+			t.Error(err)
+		}
+	})
+
+	if err := docSet.Folder().Delete(); err != nil {
+		t.Error(err)
+	}
+
+}
@@ -0,0 +1,302 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pnocera/gosip"
+)
+
+// ErrFileChanged is returned by DownloadTo methods when the file was modified since the download started
+// or since the partial download described by DownloadOptions.ETag
+var ErrFileChanged = errors.New("file was changed during the download")
+
+// DownloadOptions provides optional settings for DownloadTo methods
+type DownloadOptions struct {
+	Offset      int64                                // number of bytes already written, the download resumes from the offset, use DownloadInfo.Downloaded of the failed download
+	ETag        string                               // ETag of the partially downloaded content, resuming fails with ErrFileChanged when the file was modified
+	SegmentSize int64                                // range request size in bytes, default is 8 MB
+	Concurrency int                                  // number of parallel range requests, default is 4
+	Progress    func(progress *DownloadProgressData) // on progress callback
+}
+
+// DownloadProgressData describes Progress callback options
+type DownloadProgressData struct {
+	Downloaded int64  // number of bytes written including resumed offset
+	Length     int64  // content length, -1 when unknown
+	ETag       string // downloaded content ETag
+}
+
+// DownloadInfo describes download results, is returned with errors as well to resume the download
+type DownloadInfo struct {
+	Downloaded int64  // number of contiguous bytes written from the beginning, should be used as DownloadOptions.Offset to resume
+	Length     int64  // content length, -1 when unknown
+	ETag       string // downloaded content ETag, should be used as DownloadOptions.ETag to resume
+}
+
+// DownloadTo downloads file content to the writer using parallel HTTP Range requests.
+// The file ETag and Length are checked before the download and the ETag of each range response
+// is verified to detect changes in the middle of the download.
+func (file *File) DownloadTo(w io.WriterAt, options *DownloadOptions) (*DownloadInfo, error) {
+	data, err := NewFile(file.client, file.endpoint, file.config).Select("ETag,Length").Get()
+	if err != nil {
+		return nil, err
+	}
+	fileInfo := data.Data()
+	info := &DownloadInfo{Length: int64(fileInfo.Length), ETag: fileInfo.ETag}
+	d := &rangeDownloader{
+		client:   file.client,
+		config:   file.config,
+		endpoint: fmt.Sprintf("%s/$value", file.endpoint),
+	}
+	return d.download(w, options, info)
+}
+
+// DownloadTo downloads attachment content to the writer using parallel HTTP Range requests,
+// see File.DownloadTo for details. Attachment length and ETag are received from the first range response.
+func (attachment *Attachment) DownloadTo(w io.WriterAt, options *DownloadOptions) (*DownloadInfo, error) {
+	d := &rangeDownloader{
+		client:   attachment.client,
+		config:   attachment.config,
+		endpoint: fmt.Sprintf("%s/$value", attachment.endpoint),
+	}
+	return d.download(w, options, &DownloadInfo{Length: -1})
+}
+
+// rangeDownloader downloads content with HTTP Range requests
+type rangeDownloader struct {
+	client   *gosip.SPClient
+	config   *RequestConfig
+	endpoint string
+
+	options  *DownloadOptions
+	info     *DownloadInfo
+	mu       sync.Mutex
+	written  int64           // number of written bytes including the offset
+	complete map[int64]int64 // completed segments ends by starts
+	cursor   int64           // contiguous downloaded bytes
+	err      error           // first segment download error
+}
+
+// download downloads the content from the offset, `info` contains known content length and ETag
+func (d *rangeDownloader) download(w io.WriterAt, options *DownloadOptions, info *DownloadInfo) (*DownloadInfo, error) {
+	opts := DownloadOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = 8 * 1024 * 1024
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	d.options = &opts
+	d.info = info
+	d.written = opts.Offset
+	d.cursor = opts.Offset
+	d.complete = map[int64]int64{}
+	info.Downloaded = opts.Offset
+
+	if opts.ETag != "" {
+		if info.ETag != "" && !sameETag(info.ETag, opts.ETag) {
+			return info, ErrFileChanged
+		}
+		info.ETag = opts.ETag
+	}
+	if info.Length >= 0 && opts.Offset >= info.Length {
+		return info, nil
+	}
+
+	// The first segment defines content length and ETag when they are not known
+	// and checks if range requests are supported at all
+	ranged, err := d.downloadSegment(w, opts.Offset, opts.Offset+opts.SegmentSize)
+	if err != nil || !ranged {
+		return d.result(), err
+	}
+
+	var segments []int64
+	for start := opts.Offset + opts.SegmentSize; start < d.info.Length; start += opts.SegmentSize {
+		segments = append(segments, start)
+	}
+
+	var wg sync.WaitGroup
+	queue := make(chan int64)
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range queue {
+				if _, err := d.downloadSegment(w, start, start+opts.SegmentSize); err != nil {
+					d.fail(err)
+				}
+			}
+		}()
+	}
+	for _, start := range segments {
+		if d.failed() != nil {
+			break
+		}
+		queue <- start
+	}
+	close(queue)
+	wg.Wait()
+
+	return d.result(), d.failed()
+}
+
+// downloadSegment downloads [start, end) range, returns false when the server responded with the whole content
+func (d *rangeDownloader) downloadSegment(w io.WriterAt, start int64, end int64) (bool, error) {
+	req, err := http.NewRequest("GET", d.endpoint, nil)
+	if err != nil {
+		return false, fmt.Errorf("unable to create a request: %w", err)
+	}
+	if d.config != nil && d.config.Context != nil {
+		req = req.WithContext(d.config.Context)
+	}
+	for key, value := range getConfHeaders(d.config) {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end-1))
+
+	resp, err := d.client.Execute(req)
+	if err != nil {
+		return false, err
+	}
+	defer shut(resp.Body)
+
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		d.mu.Lock()
+		if d.info.ETag == "" {
+			d.info.ETag = etag
+		}
+		changed := !sameETag(d.info.ETag, etag)
+		d.mu.Unlock()
+		if changed {
+			return false, ErrFileChanged
+		}
+	}
+
+	// Range requests are not supported, the whole content is written skipping the offset
+	if resp.StatusCode != http.StatusPartialContent {
+		if _, err := io.CopyN(io.Discard, resp.Body, start); err != nil {
+			return false, err
+		}
+		d.mu.Lock()
+		if d.info.Length < 0 {
+			d.info.Length = resp.ContentLength + start
+		}
+		d.mu.Unlock()
+		return false, d.copySegment(w, resp.Body, start, -1)
+	}
+
+	total := parseContentRangeTotal(resp.Header.Get("Content-Range"))
+	d.mu.Lock()
+	if d.info.Length < 0 {
+		d.info.Length = total
+	}
+	changed := total >= 0 && total != d.info.Length
+	d.mu.Unlock()
+	if changed {
+		return true, ErrFileChanged
+	}
+
+	return true, d.copySegment(w, resp.Body, start, end)
+}
+
+// copySegment writes the body at the segment position reporting the progress, `end` is -1 for the whole body
+func (d *rangeDownloader) copySegment(w io.WriterAt, body io.Reader, start int64, end int64) error {
+	buf := make([]byte, 32*1024)
+	pos := start
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if _, werr := w.WriteAt(buf[:n], pos); werr != nil {
+				return werr
+			}
+			pos += int64(n)
+			d.mu.Lock()
+			d.written += int64(n)
+			if end < 0 {
+				d.cursor = pos
+			}
+			progress := &DownloadProgressData{Downloaded: d.written, Length: d.info.Length, ETag: d.info.ETag}
+			if d.options.Progress != nil {
+				d.options.Progress(progress)
+			}
+			d.mu.Unlock()
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if end >= 0 && pos < end && pos < d.info.Length {
+		return fmt.Errorf("incomplete range response, expected %d bytes, received %d", end-start, pos-start)
+	}
+	if end >= 0 {
+		d.mu.Lock()
+		d.complete[start] = pos
+		for next, ok := d.complete[d.cursor]; ok; next, ok = d.complete[d.cursor] {
+			delete(d.complete, d.cursor)
+			d.cursor = next
+		}
+		d.mu.Unlock()
+	}
+	return nil
+}
+
+// fail keeps the first segment download error
+func (d *rangeDownloader) fail(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+// failed gets the first segment download error
+func (d *rangeDownloader) failed() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.err
+}
+
+// result gets download info with the contiguous downloaded bytes
+func (d *rangeDownloader) result() *DownloadInfo {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.info.Downloaded = d.cursor
+	return d.info
+}
+
+// contentRangeRegExp parses `bytes 0-99/1234` Content-Range header
+var contentRangeRegExp = regexp.MustCompile(`^bytes\s+\d+-\d+/(\d+)$`)
+
+// parseContentRangeTotal gets total length from Content-Range header, -1 when unknown
+func parseContentRangeTotal(contentRange string) int64 {
+	m := contentRangeRegExp.FindStringSubmatch(strings.TrimSpace(contentRange))
+	if len(m) < 2 {
+		return -1
+	}
+	total, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return total
+}
+
+// sameETag compares ETags ignoring weak validator prefix and quotes
+func sameETag(a string, b string) bool {
+	normalize := func(etag string) string {
+		return strings.Trim(strings.TrimPrefix(strings.TrimSpace(etag), "W/"), `"`)
+	}
+	return strings.EqualFold(normalize(a), normalize(b))
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDownload(t *testing.T) {
+
+	t.Run("ParseContentRange", func(t *testing.T) {
+		if total := parseContentRangeTotal("bytes 0-99/1234"); total != 1234 {
+			t.Errorf("incorrect total: %d", total)
+		}
+		if total := parseContentRangeTotal("bytes */1234"); total != -1 {
+			t.Errorf("incorrect unknown total: %d", total)
+		}
+	})
+
+	t.Run("SameETag", func(t *testing.T) {
+		if !sameETag(`"{5E1B7C5B-8D55-4B52-9A53-1A4E1E2B7E0D},3"`, `W/"{5e1b7c5b-8d55-4b52-9a53-1a4e1e2b7e0d},3"`) {
+			t.Error("ETags should match")
+		}
+		if sameETag(`"{5E1B7C5B-8D55-4B52-9A53-1A4E1E2B7E0D},3"`, `"{5E1B7C5B-8D55-4B52-9A53-1A4E1E2B7E0D},4"`) {
+			t.Error("ETags should not match")
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	newFolderName := uuid.New().String()
+	rootFolderURI := getRelativeURL(spClient.AuthCnfg.GetSiteURL()) + "/Shared%20Documents"
+	newFolderURI := rootFolderURI + "/" + newFolderName
+	if _, err := web.GetFolder(rootFolderURI).Folders().Add(newFolderName); err != nil {
+		t.Error(err)
+	}
+
+	content := bytes.Repeat([]byte("0123456789"), 100)
+	fileResp, err := web.GetFolder(newFolderURI).Files().Add("download.txt", content, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := web.GetFile(fileResp.Data().ServerRelativeURL)
+
+	t.Run("DownloadTo", func(t *testing.T) {
+		w := &memoryWriterAt{}
+		reported := int64(0)
+		info, err := file.DownloadTo(w, &DownloadOptions{
+			SegmentSize: 128,
+			Concurrency: 3,
+			Progress:    func(p *DownloadProgressData) { reported = p.Downloaded },
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(w.data, content) {
+			t.Error("incorrect downloaded content")
+		}
+		if info.Downloaded != int64(len(content)) || reported != info.Downloaded {
+			t.Errorf("incorrect downloaded length: %d, reported %d", info.Downloaded, reported)
+		}
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		w := &memoryWriterAt{data: append([]byte{}, content[:300]...)}
+		info, err := file.DownloadTo(w, &DownloadOptions{Offset: 300, SegmentSize: 256})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(w.data, content) {
+			t.Error("incorrect resumed content")
+		}
+
+		if _, err := web.GetFolder(newFolderURI).Files().Add("download.txt", []byte("changed"), true); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := file.DownloadTo(&memoryWriterAt{}, &DownloadOptions{Offset: 300, ETag: info.ETag}); !errors.Is(err, ErrFileChanged) {
+			t.Errorf("file change should be detected, got %v", err)
+		}
+	})
+
+	if err := web.GetFolder(newFolderURI).Delete(); err != nil {
+		t.Error(err)
+	}
+}
+
+// memoryWriterAt is in-memory io.WriterAt
+type memoryWriterAt struct {
+	data []byte
+	mu   sync.Mutex
+}
+
+func (w *memoryWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if end := int(off) + len(p); end > len(w.data) {
+		w.data = append(w.data, make([]byte, end-len(w.data))...)
+	}
+	copy(w.data[off:], p)
+	return len(p), nil
+}
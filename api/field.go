@@ -6,7 +6,7 @@ import (
 	"github.com/pnocera/gosip"
 )
 
-//go:generate ggen -ent Field -conf -mods Select,Expand -helpers Data,Normalized
+//go:generate ggen -ent Field -conf -etag -mods Select,Expand -helpers Data,Normalized
 
 // Field represents SharePoint Field (Site Column) API queryable object struct
 // Always use NewField constructor instead of &Field{}
@@ -43,3 +43,36 @@ func (fieldLinksResp *FieldLinksResp) Normalized() []byte {
 	normalized, _ := NormalizeODataCollection(*fieldLinksResp)
 	return normalized
 }
+
+/* Pagination helpers */
+
+// FieldLinksPager - FieldLinks collection pages iterator
+type FieldLinksPager struct {
+	*Pager
+}
+
+// Pager gets FieldLinks collection pages iterator, the iteration follows OData next page links
+func (fieldLinks *FieldLinks) Pager() *FieldLinksPager {
+	return &FieldLinksPager{NewPager(fieldLinks.client, fieldLinks.ToURL(), fieldLinks.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *FieldLinksPager) Prefetch(enabled bool) *FieldLinksPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *FieldLinksPager) Page() FieldLinksResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (fieldLinksResp *FieldLinksResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*fieldLinksResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (fieldLinksResp *FieldLinksResp) HasNextPage() bool {
+	return fieldLinksResp.NextPageURL() != ""
+}
@@ -0,0 +1,532 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldFormValuer is implemented by typed field values which can be written
+// with AddValidate and UpdateValidate methods (Items.AddValidate, Item.UpdateValidate)
+type FieldFormValuer interface {
+	FormValue() string // gets field value in the format expected by ValidateUpdateListItem
+}
+
+// FieldValues - item field values map, marshals typed field values to Items.Add and Item.Update payload.
+// Lookup and people fields are written to `{InternalName}Id` properties automatically.
+type FieldValues map[string]interface{}
+
+// FieldLookupValue - lookup field value
+type FieldLookupValue struct {
+	ID    int    // lookup item ID
+	Value string // lookup item display value, not required for writing
+}
+
+// FieldLookupValues - multi lookup field value
+type FieldLookupValues []FieldLookupValue
+
+// FieldUserValue - people field value, either ID or LoginName should be provided for writing
+type FieldUserValue struct {
+	ID        int    // site user ID, required for Items.Add and Item.Update
+	LoginName string // user or group login name, e.g. `i:0#.f|membership|user@contoso.com`, required for AddValidate
+	Email     string // user email, read only
+	Title     string // user display name, read only
+}
+
+// FieldUserValues - multi people field value
+type FieldUserValues []FieldUserValue
+
+// TaxonomyFieldValue - managed metadata field value
+type TaxonomyFieldValue struct {
+	Label    string // term label
+	TermGUID string // term ID
+	WssID    int    // taxonomy hidden list item ID, -1 when is not known
+}
+
+// TaxonomyFieldValues - multi managed metadata field value,
+// REST payload can't set multi taxonomy fields, use FormValue with AddValidate and UpdateValidate for writing
+type TaxonomyFieldValues []TaxonomyFieldValue
+
+// FieldURLValue - hyperlink or picture field value
+type FieldURLValue struct {
+	URL         string // link URL
+	Description string // link description
+}
+
+// FieldGeolocationValue - geolocation field value
+type FieldGeolocationValue struct {
+	Latitude  float64
+	Longitude float64
+	Altitude  float64
+	Measure   float64
+}
+
+// FieldMultiChoiceValue - multi choice field value
+type FieldMultiChoiceValue []string
+
+/* FieldValues */
+
+// MarshalJSON marshals field values to OData verbose payload
+func (values FieldValues) MarshalJSON() ([]byte, error) {
+	payload := map[string]interface{}{}
+	for name, value := range values {
+		switch value.(type) {
+		case FieldLookupValue, *FieldLookupValue, FieldLookupValues, FieldUserValue, *FieldUserValue, FieldUserValues:
+			if !strings.HasSuffix(name, "Id") {
+				name += "Id"
+			}
+		}
+		payload[name] = value
+	}
+	return json.Marshal(payload)
+}
+
+// FormValues converts field values to AddValidate and UpdateValidate form values,
+// time.Time values are formatted in UTC with en-US layout, use strings for other regional settings
+func (values FieldValues) FormValues() map[string]string {
+	formValues := map[string]string{}
+	for name, value := range values {
+		formValues[name] = fieldFormValue(value)
+	}
+	return formValues
+}
+
+// fieldFormValue converts typed or primitive value to ValidateUpdateListItem form value
+func fieldFormValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case FieldFormValuer:
+		return v.FormValue()
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case time.Time:
+		return v.UTC().Format("1/2/2006 3:04 PM")
+	case []string:
+		return strings.Join(v, ";#")
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+/* Lookup */
+
+// MarshalJSON marshals lookup value to `{InternalName}Id` property value
+func (v FieldLookupValue) MarshalJSON() ([]byte, error) {
+	if v.ID == 0 {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v.ID)
+}
+
+// UnmarshalJSON unmarshals lookup value from `{InternalName}Id` number or expanded lookup object
+func (v *FieldLookupValue) UnmarshalJSON(data []byte) error {
+	raw, err := unmarshalFieldValue(data)
+	if err != nil || raw == nil {
+		return err
+	}
+	*v = FieldLookupValue{}
+	if m, ok := raw.(map[string]interface{}); ok {
+		v.ID = fieldValueInt(m, "LookupId", "Id", "ID")
+		v.Value = fieldValueString(m, "LookupValue", "Title")
+		if v.Value == "" {
+			for key, val := range m {
+				if s, ok := val.(string); ok && key != "__metadata" && !strings.HasPrefix(key, "odata.") {
+					v.Value = s
+					break
+				}
+			}
+		}
+		return nil
+	}
+	v.ID = fieldValueToInt(raw)
+	return nil
+}
+
+// FormValue gets lookup value in ValidateUpdateListItem format
+func (v FieldLookupValue) FormValue() string {
+	if v.ID == 0 {
+		return ""
+	}
+	return strconv.Itoa(v.ID)
+}
+
+// MarshalJSON marshals multi lookup value to `{InternalName}Id` property value
+func (v FieldLookupValues) MarshalJSON() ([]byte, error) {
+	ids := []int{}
+	for _, l := range v {
+		ids = append(ids, l.ID)
+	}
+	return json.Marshal(map[string]interface{}{"results": ids})
+}
+
+// UnmarshalJSON unmarshals multi lookup value from IDs collection or expanded lookups objects
+func (v *FieldLookupValues) UnmarshalJSON(data []byte) error {
+	values, err := unmarshalFieldCollection(data)
+	if err != nil {
+		return err
+	}
+	*v = FieldLookupValues{}
+	for _, val := range values {
+		l := FieldLookupValue{}
+		if err := l.UnmarshalJSON(val); err != nil {
+			return err
+		}
+		*v = append(*v, l)
+	}
+	return nil
+}
+
+// FormValue gets multi lookup value in ValidateUpdateListItem format
+func (v FieldLookupValues) FormValue() string {
+	ids := []string{}
+	for _, l := range v {
+		ids = append(ids, strconv.Itoa(l.ID))
+	}
+	return strings.Join(ids, ";#;#")
+}
+
+/* People */
+
+// MarshalJSON marshals people value to `{InternalName}Id` property value
+func (v FieldUserValue) MarshalJSON() ([]byte, error) {
+	if v.ID == 0 {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v.ID)
+}
+
+// UnmarshalJSON unmarshals people value from `{InternalName}Id` number or expanded user object
+func (v *FieldUserValue) UnmarshalJSON(data []byte) error {
+	raw, err := unmarshalFieldValue(data)
+	if err != nil || raw == nil {
+		return err
+	}
+	*v = FieldUserValue{}
+	if m, ok := raw.(map[string]interface{}); ok {
+		v.ID = fieldValueInt(m, "LookupId", "Id", "ID")
+		v.LoginName = fieldValueString(m, "Name", "LoginName")
+		v.Email = fieldValueString(m, "EMail", "Email")
+		v.Title = fieldValueString(m, "Title", "LookupValue")
+		return nil
+	}
+	v.ID = fieldValueToInt(raw)
+	return nil
+}
+
+// FormValue gets people value in ValidateUpdateListItem format, requires LoginName
+func (v FieldUserValue) FormValue() string {
+	return FieldUserValues{v}.FormValue()
+}
+
+// MarshalJSON marshals multi people value to `{InternalName}Id` property value
+func (v FieldUserValues) MarshalJSON() ([]byte, error) {
+	ids := []int{}
+	for _, u := range v {
+		ids = append(ids, u.ID)
+	}
+	return json.Marshal(map[string]interface{}{"results": ids})
+}
+
+// UnmarshalJSON unmarshals multi people value from IDs collection or expanded users objects
+func (v *FieldUserValues) UnmarshalJSON(data []byte) error {
+	values, err := unmarshalFieldCollection(data)
+	if err != nil {
+		return err
+	}
+	*v = FieldUserValues{}
+	for _, val := range values {
+		u := FieldUserValue{}
+		if err := u.UnmarshalJSON(val); err != nil {
+			return err
+		}
+		*v = append(*v, u)
+	}
+	return nil
+}
+
+// FormValue gets multi people value in ValidateUpdateListItem format, requires LoginName
+func (v FieldUserValues) FormValue() string {
+	type userKey struct {
+		Key string `json:"Key"`
+	}
+	keys := []userKey{}
+	for _, u := range v {
+		keys = append(keys, userKey{Key: u.LoginName})
+	}
+	res, _ := json.Marshal(keys)
+	return string(res)
+}
+
+/* Managed metadata */
+
+// MarshalJSON marshals managed metadata value to OData verbose payload
+func (v TaxonomyFieldValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.verbose())
+}
+
+func (v TaxonomyFieldValue) verbose() map[string]interface{} {
+	wssID := v.WssID
+	if wssID == 0 {
+		wssID = -1
+	}
+	return map[string]interface{}{
+		"__metadata": map[string]string{"type": "SP.Taxonomy.TaxonomyFieldValue"},
+		"Label":      v.Label,
+		"TermGuid":   v.TermGUID,
+		"WssId":      wssID,
+	}
+}
+
+// UnmarshalJSON unmarshals managed metadata value
+func (v *TaxonomyFieldValue) UnmarshalJSON(data []byte) error {
+	raw, err := unmarshalFieldValue(data)
+	if err != nil || raw == nil {
+		return err
+	}
+	*v = TaxonomyFieldValue{}
+	if m, ok := raw.(map[string]interface{}); ok {
+		v.Label = fieldValueString(m, "Label")
+		v.TermGUID = fieldValueString(m, "TermGuid")
+		v.WssID = fieldValueInt(m, "WssId")
+	}
+	return nil
+}
+
+// FormValue gets managed metadata value in ValidateUpdateListItem format
+func (v TaxonomyFieldValue) FormValue() string {
+	if v.TermGUID == "" {
+		return ""
+	}
+	return v.Label + "|" + v.TermGUID
+}
+
+// MarshalJSON marshals multi managed metadata value to OData verbose collection
+func (v TaxonomyFieldValues) MarshalJSON() ([]byte, error) {
+	results := []interface{}{}
+	for _, t := range v {
+		results = append(results, t.verbose())
+	}
+	return json.Marshal(map[string]interface{}{
+		"__metadata": map[string]string{"type": "Collection(SP.Taxonomy.TaxonomyFieldValue)"},
+		"results":    results,
+	})
+}
+
+// UnmarshalJSON unmarshals multi managed metadata value
+func (v *TaxonomyFieldValues) UnmarshalJSON(data []byte) error {
+	values, err := unmarshalFieldCollection(data)
+	if err != nil {
+		return err
+	}
+	*v = TaxonomyFieldValues{}
+	for _, val := range values {
+		t := TaxonomyFieldValue{}
+		if err := t.UnmarshalJSON(val); err != nil {
+			return err
+		}
+		*v = append(*v, t)
+	}
+	return nil
+}
+
+// FormValue gets multi managed metadata value in ValidateUpdateListItem format
+func (v TaxonomyFieldValues) FormValue() string {
+	terms := []string{}
+	for _, t := range v {
+		terms = append(terms, t.FormValue())
+	}
+	return strings.Join(terms, ";")
+}
+
+/* Hyperlink */
+
+// MarshalJSON marshals hyperlink value to OData verbose payload
+func (v FieldURLValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"__metadata":  map[string]string{"type": "SP.FieldUrlValue"},
+		"Url":         v.URL,
+		"Description": v.Description,
+	})
+}
+
+// UnmarshalJSON unmarshals hyperlink value
+func (v *FieldURLValue) UnmarshalJSON(data []byte) error {
+	raw, err := unmarshalFieldValue(data)
+	if err != nil || raw == nil {
+		return err
+	}
+	*v = FieldURLValue{}
+	if m, ok := raw.(map[string]interface{}); ok {
+		v.URL = fieldValueString(m, "Url")
+		v.Description = fieldValueString(m, "Description")
+	}
+	return nil
+}
+
+// FormValue gets hyperlink value in ValidateUpdateListItem format
+func (v FieldURLValue) FormValue() string {
+	if v.Description == "" {
+		return v.URL
+	}
+	return v.URL + ", " + v.Description
+}
+
+/* Geolocation */
+
+// MarshalJSON marshals geolocation value to OData verbose payload
+func (v FieldGeolocationValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"__metadata": map[string]string{"type": "SP.FieldGeolocationValue"},
+		"Latitude":   v.Latitude,
+		"Longitude":  v.Longitude,
+		"Altitude":   v.Altitude,
+		"Measure":    v.Measure,
+	})
+}
+
+// UnmarshalJSON unmarshals geolocation value
+func (v *FieldGeolocationValue) UnmarshalJSON(data []byte) error {
+	raw, err := unmarshalFieldValue(data)
+	if err != nil || raw == nil {
+		return err
+	}
+	*v = FieldGeolocationValue{}
+	if m, ok := raw.(map[string]interface{}); ok {
+		v.Latitude = fieldValueFloat(m["Latitude"])
+		v.Longitude = fieldValueFloat(m["Longitude"])
+		v.Altitude = fieldValueFloat(m["Altitude"])
+		v.Measure = fieldValueFloat(m["Measure"])
+	}
+	return nil
+}
+
+// FormValue gets geolocation value in ValidateUpdateListItem format (WKT point)
+func (v FieldGeolocationValue) FormValue() string {
+	return fmt.Sprintf(
+		"POINT (%s %s)",
+		strconv.FormatFloat(v.Longitude, 'f', -1, 64),
+		strconv.FormatFloat(v.Latitude, 'f', -1, 64),
+	)
+}
+
+/* Multi choice */
+
+// MarshalJSON marshals multi choice value to OData verbose collection
+func (v FieldMultiChoiceValue) MarshalJSON() ([]byte, error) {
+	results := []string{}
+	results = append(results, v...)
+	return json.Marshal(map[string]interface{}{
+		"__metadata": map[string]string{"type": "Collection(Edm.String)"},
+		"results":    results,
+	})
+}
+
+// UnmarshalJSON unmarshals multi choice value
+func (v *FieldMultiChoiceValue) UnmarshalJSON(data []byte) error {
+	values, err := unmarshalFieldCollection(data)
+	if err != nil {
+		return err
+	}
+	*v = FieldMultiChoiceValue{}
+	for _, val := range values {
+		var s string
+		if err := json.Unmarshal(val, &s); err != nil {
+			return err
+		}
+		*v = append(*v, s)
+	}
+	return nil
+}
+
+// FormValue gets multi choice value in ValidateUpdateListItem format
+func (v FieldMultiChoiceValue) FormValue() string {
+	return strings.Join(v, ";#")
+}
+
+/* Helpers */
+
+// unmarshalFieldValue unmarshals a field value skipping deferred (not expanded) verbose objects
+func unmarshalFieldValue(data []byte) (interface{}, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if m, ok := raw.(map[string]interface{}); ok {
+		if _, deferred := m["__deferred"]; deferred {
+			return nil, nil
+		}
+	}
+	return raw, nil
+}
+
+// unmarshalFieldCollection unmarshals a collection value from either verbose `{"results":[]}` or an array
+func unmarshalFieldCollection(data []byte) ([]json.RawMessage, error) {
+	raw, err := unmarshalFieldValue(data)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	if m, ok := raw.(map[string]interface{}); ok {
+		raw = m["results"]
+	}
+	if raw == nil {
+		return nil, nil
+	}
+	arr, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("can't unmarshal %s as a collection", data)
+	}
+	var values []json.RawMessage
+	for _, val := range arr {
+		b, _ := json.Marshal(val)
+		values = append(values, b)
+	}
+	return values, nil
+}
+
+// fieldValueInt gets the first found property as int
+func fieldValueInt(m map[string]interface{}, props ...string) int {
+	for _, prop := range props {
+		if val, ok := m[prop]; ok && val != nil {
+			return fieldValueToInt(val)
+		}
+	}
+	return 0
+}
+
+// fieldValueString gets the first found non empty string property
+func fieldValueString(m map[string]interface{}, props ...string) string {
+	for _, prop := range props {
+		if val, ok := m[prop].(string); ok && val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
+// fieldValueToInt converts a JSON number or a numeric string to int
+func fieldValueToInt(val interface{}) int {
+	return int(fieldValueFloat(val))
+}
+
+// fieldValueFloat converts a JSON number or a numeric string (verbose Edm.Double) to float64
+func fieldValueFloat(val interface{}) float64 {
+	switch v := val.(type) {
+	case float64:
+		return v
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	return 0
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFieldValues(t *testing.T) {
+
+	t.Run("MarshalJSON", func(t *testing.T) {
+		values := FieldValues{
+			"Title":   "Item",
+			"Lookup":  FieldLookupValue{ID: 3},
+			"Users":   FieldUserValues{{ID: 7}, {ID: 8}},
+			"Link":    FieldURLValue{URL: "https://contoso.com", Description: "Contoso"},
+			"Choices": FieldMultiChoiceValue{"A", "B"},
+		}
+		data, err := json.Marshal(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := map[string]interface{}{}
+		_ = json.Unmarshal(data, &res)
+		if res["LookupId"] != 3.0 {
+			t.Errorf("incorrect lookup payload: %s", data)
+		}
+		if _, ok := res["UsersId"].(map[string]interface{})["results"]; !ok {
+			t.Errorf("incorrect multi user payload: %s", data)
+		}
+		if !strings.Contains(string(data), `"type":"SP.FieldUrlValue"`) {
+			t.Errorf("incorrect hyperlink payload: %s", data)
+		}
+		if !strings.Contains(string(data), `"results":["A","B"]`) {
+			t.Errorf("incorrect multi choice payload: %s", data)
+		}
+	})
+
+	t.Run("FormValues", func(t *testing.T) {
+		values := FieldValues{
+			"Lookups":  FieldLookupValues{{ID: 1}, {ID: 2}},
+			"User":     FieldUserValue{LoginName: "i:0#.f|membership|user@contoso.com"},
+			"Taxonomy": TaxonomyFieldValues{{Label: "A", TermGUID: "g1"}, {Label: "B", TermGUID: "g2"}},
+			"Link":     FieldURLValue{URL: "https://contoso.com", Description: "Contoso"},
+			"Flag":     true,
+		}
+		expected := map[string]string{
+			"Lookups":  "1;#;#2",
+			"User":     `[{"Key":"i:0#.f|membership|user@contoso.com"}]`,
+			"Taxonomy": "A|g1;B|g2",
+			"Link":     "https://contoso.com, Contoso",
+			"Flag":     "1",
+		}
+		for name, value := range values.FormValues() {
+			if value != expected[name] {
+				t.Errorf("incorrect %s form value, expected \"%s\", got \"%s\"", name, expected[name], value)
+			}
+		}
+	})
+
+	t.Run("UnmarshalJSON/Lookup", func(t *testing.T) {
+		payloads := []string{
+			`{"__metadata":{"type":"SP.Data.LookupListItem"},"Id":3,"Title":"Three"}`, // verbose
+			`{"odata.type":"SP.Data.LookupListItem","Id":3,"Title":"Three"}`,          // minimalmetadata
+			`{"Id":3,"Title":"Three"}`,             // nometadata
+			`{"LookupId":3,"LookupValue":"Three"}`, // item version
+		}
+		for _, p := range payloads {
+			v := FieldLookupValue{}
+			if err := json.Unmarshal([]byte(p), &v); err != nil {
+				t.Error(err)
+			}
+			if v.ID != 3 || v.Value != "Three" {
+				t.Errorf("incorrect lookup value from %s: %+v", p, v)
+			}
+		}
+		v := FieldLookupValue{}
+		_ = json.Unmarshal([]byte(`3`), &v)
+		if v.ID != 3 {
+			t.Error("incorrect lookup value from ID")
+		}
+	})
+
+	t.Run("UnmarshalJSON/Collections", func(t *testing.T) {
+		verbose := `{"Users":{"results":[{"__metadata":{"type":"SP.Data.UserInfoItem"},"Id":7,"Name":"user","EMail":"user@contoso.com","Title":"User"}]},"Choices":{"__metadata":{"type":"Collection(Edm.String)"},"results":["A","B"]},"Link":{"__metadata":{"type":"SP.FieldUrlValue"},"Url":"https://contoso.com","Description":"Contoso"},"Geo":{"__metadata":{"type":"SP.FieldGeolocationValue"},"Latitude":"50.1","Longitude":"30.2"},"Lookups":{"__deferred":{"uri":"https://contoso.sharepoint.com/_api/Web/Lists/Items(1)/Lookups"}}}`
+		nometadata := `{"Users":[{"Id":7,"Name":"user","EMail":"user@contoso.com","Title":"User"}],"Choices":["A","B"],"Link":{"Url":"https://contoso.com","Description":"Contoso"},"Geo":{"Latitude":50.1,"Longitude":30.2},"Lookups":null}`
+		for _, p := range []string{verbose, nometadata} {
+			v := &struct {
+				Users   FieldUserValues
+				Choices FieldMultiChoiceValue
+				Link    FieldURLValue
+				Geo     FieldGeolocationValue
+				Lookups FieldLookupValues
+			}{}
+			if err := json.Unmarshal([]byte(p), &v); err != nil {
+				t.Fatal(err)
+			}
+			if len(v.Users) != 1 || v.Users[0].LoginName != "user" || v.Users[0].Email != "user@contoso.com" {
+				t.Errorf("incorrect users value: %+v", v.Users)
+			}
+			if len(v.Choices) != 2 {
+				t.Errorf("incorrect choices value: %+v", v.Choices)
+			}
+			if v.Link.URL != "https://contoso.com" {
+				t.Errorf("incorrect link value: %+v", v.Link)
+			}
+			if v.Geo.Latitude != 50.1 {
+				t.Errorf("incorrect geolocation value: %+v", v.Geo)
+			}
+			if len(v.Lookups) != 0 {
+				t.Errorf("incorrect deferred lookups value: %+v", v.Lookups)
+			}
+		}
+	})
+
+	t.Run("UnmarshalJSON/Taxonomy", func(t *testing.T) {
+		v := TaxonomyFieldValues{}
+		p := `{"__metadata":{"type":"Collection(SP.Taxonomy.TaxonomyFieldValue)"},"results":[{"__metadata":{"type":"SP.Taxonomy.TaxonomyFieldValue"},"Label":"A","TermGuid":"g1","WssId":1}]}`
+		if err := json.Unmarshal([]byte(p), &v); err != nil {
+			t.Fatal(err)
+		}
+		if len(v) != 1 || v[0].TermGUID != "g1" || v[0].WssID != 1 {
+			t.Errorf("incorrect taxonomy value: %+v", v)
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	newListTitle := strings.Replace(uuid.New().String(), "-", "", -1)
+	if _, err := web.Lists().Add(newListTitle, nil); err != nil {
+		t.Error(err)
+	}
+	list := web.Lists().GetByTitle(newListTitle)
+	if _, err := list.Fields().CreateFieldAsXML(`<Field Type="URL" DisplayName="Link" Name="Link" />`, 12); err != nil {
+		t.Error(err)
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		body, _ := json.Marshal(FieldValues{
+			"Title": "Item",
+			"Link":  FieldURLValue{URL: "https://contoso.com", Description: "Contoso"},
+		})
+		item, err := list.Items().Add(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, conf := range []*RequestConfig{headers.verbose, headers.minimalmetadata, headers.nometadata} {
+			data, err := list.Items().GetByID(item.Data().ID).Conf(conf).Select("Link").Get()
+			if err != nil {
+				t.Fatal(err)
+			}
+			v := &struct{ Link FieldURLValue }{}
+			if err := json.Unmarshal(data.Normalized(), &v); err != nil {
+				t.Error(err)
+			}
+			if v.Link.Description != "Contoso" {
+				t.Errorf("incorrect link value: %+v", v.Link)
+			}
+		}
+	})
+
+	if err := list.Delete(); err != nil {
+		t.Error(err)
+	}
+
+}
@@ -1,4 +1,4 @@
-// Code generated by `ggen -ent Field -conf -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
+// Code generated by `ggen -ent Field -conf -etag -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
 
 package api
 
@@ -38,3 +38,18 @@ func (fieldResp *FieldResp) Data() *FieldInfo {
 func (fieldResp *FieldResp) Normalized() []byte {
 	return NormalizeODataItem(*fieldResp)
 }
+
+/* Optimistic concurrency helpers */
+
+// IfMatch gets a copy of the entity with the expected ETag for Update and Delete requests,
+// the requests fail with *PreconditionFailedError when the entity was modified since the ETag was received
+func (field *Field) IfMatch(etag string) *Field {
+	scoped := *field
+	scoped.config = withIfMatch(field.config, etag)
+	return &scoped
+}
+
+// ETag gets entity ETag from the response metadata
+func (fieldResp *FieldResp) ETag() string {
+	return getODataETag(*fieldResp)
+}
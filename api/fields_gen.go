@@ -55,3 +55,36 @@ func (fieldsResp *FieldsResp) Normalized() []byte {
 	normalized, _ := NormalizeODataCollection(*fieldsResp)
 	return normalized
 }
+
+/* Pagination helpers */
+
+// FieldsPager - Fields collection pages iterator
+type FieldsPager struct {
+	*Pager
+}
+
+// Pager gets Fields collection pages iterator, the iteration follows OData next page links
+func (fields *Fields) Pager() *FieldsPager {
+	return &FieldsPager{NewPager(fields.client, fields.ToURL(), fields.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *FieldsPager) Prefetch(enabled bool) *FieldsPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *FieldsPager) Page() FieldsResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (fieldsResp *FieldsResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*fieldsResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (fieldsResp *FieldsResp) HasNextPage() bool {
+	return fieldsResp.NextPageURL() != ""
+}
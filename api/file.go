@@ -12,7 +12,7 @@ import (
 	"github.com/pnocera/gosip"
 )
 
-//go:generate ggen -ent File -conf -mods Select,Expand -helpers Data,Normalized
+//go:generate ggen -ent File -conf -etag -mods Select,Expand -helpers Data,Normalized
 
 // File represents SharePoint File API queryable object struct
 // Always use NewFile constructor instead of &File{}
@@ -150,6 +150,16 @@ func (file *File) GetItem() (*Item, error) {
 	return item, nil
 }
 
+// GetUserEffectivePermissions gets effective permissions of the user on this File list item
+func (file *File) GetUserEffectivePermissions(loginName string) (*BasePermissions, error) {
+	return getUserEffectivePermissions(file.client, fmt.Sprintf("%s/ListItemAllFields", file.endpoint), loginName, file.config)
+}
+
+// EffectiveBasePermissions gets effective permissions of the current user on this File list item
+func (file *File) EffectiveBasePermissions() (*BasePermissions, error) {
+	return getEffectiveBasePermissions(file.client, fmt.Sprintf("%s/ListItemAllFields", file.endpoint), file.config)
+}
+
 // CheckIn checks file in, checkInType: 0 - Minor, 1 - Major, 2 - Overwrite
 func (file *File) CheckIn(comment string, checkInType int) ([]byte, error) {
 	endpoint := fmt.Sprintf(
@@ -257,6 +267,28 @@ func (file *File) CopyTo(newURL string, overwrite bool) ([]byte, error) {
 	return client.Post(endpoint, nil, file.config)
 }
 
+// MoveToUsingPath moves file to new location within the same site using ResourcePath API,
+// `newURL` is a decoded server relative URL of the file
+// Supported only in modern SharePoint, differs from MoveTo with its capability of dealing with special chars in path (`'`, `#`, `%`)
+func (file *File) MoveToUsingPath(newURL string, overwrite bool) ([]byte, error) {
+	flag := 0
+	if overwrite {
+		flag = 1
+	}
+	endpoint := fmt.Sprintf("%s/MoveToUsingPath(decodedUrl='%s',moveOperations=%d)", file.endpoint, escapeResourcePath(newURL), flag)
+	client := NewHTTPClient(file.client)
+	return client.Post(endpoint, nil, file.config)
+}
+
+// CopyToUsingPath copies file to new location within the same site using ResourcePath API,
+// `newURL` is a decoded server relative URL of the file copy
+// Supported only in modern SharePoint, differs from CopyTo with its capability of dealing with special chars in path (`'`, `#`, `%`)
+func (file *File) CopyToUsingPath(newURL string, overwrite bool) ([]byte, error) {
+	endpoint := fmt.Sprintf("%s/CopyToUsingPath(decodedUrl='%s',bOverWrite=%t)", file.endpoint, escapeResourcePath(newURL), overwrite)
+	client := NewHTTPClient(file.client)
+	return client.Post(endpoint, nil, file.config)
+}
+
 // ContextInfo ...
 func (file *File) ContextInfo() (*ContextInfo, error) {
 	return NewContext(file.client, file.ToURL(), file.config).Get()
@@ -271,3 +303,12 @@ func (file *File) Props() *Properties {
 		"file",
 	)
 }
+
+// Versions gets Versions API instance queryable collection for this File
+func (file *File) Versions() *FileVersions {
+	return NewFileVersions(
+		file.client,
+		fmt.Sprintf("%s/Versions", file.endpoint),
+		file.config,
+	)
+}
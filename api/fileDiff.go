@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FileState describes file version attributes used for change detection
+type FileState struct {
+	ETag             string    // file ETag, ignored in comparison when empty
+	Length           int64     // content length in bytes, an empty file has zero length
+	LengthKnown      bool      // Length is set, the length is compared only when it is known in both states
+	TimeLastModified time.Time // last modification time, ignored in comparison when zero
+	QuickXorHash     string    // base64 encoded QuickXorHash of the content, ignored in comparison when empty
+}
+
+// FileDiff describes differences between a known file state and the server file
+type FileDiff struct {
+	ETag             bool       // ETag differs
+	Length           bool       // content length differs
+	TimeLastModified bool       // modification time differs, compared with seconds precision
+	QuickXorHash     bool       // content hash differs
+	HashCompared     bool       // content hashes were available on both sides
+	Remote           *FileState // server file state
+}
+
+// Changed checks if any compared attribute differs.
+// When content hashes were compared, equal hashes mean the same content regardless of the other attributes.
+func (diff *FileDiff) Changed() bool {
+	if diff.HashCompared {
+		return diff.QuickXorHash
+	}
+	return diff.ETag || diff.Length || diff.TimeLastModified
+}
+
+// NewLocalFileState gets local file state with the content length, modification time and QuickXorHash
+func NewLocalFileState(path string) (*FileState, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer shut(f)
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	qxh, err := QuickXorHashOf(f)
+	if err != nil {
+		return nil, err
+	}
+	return &FileState{
+		Length:           stat.Size(),
+		LengthKnown:      true,
+		TimeLastModified: stat.ModTime(),
+		QuickXorHash:     qxh,
+	}, nil
+}
+
+// State gets server file state, the content hash is received from the file property bag
+// and is available only in SharePoint Online
+func (file *File) State() (*FileState, error) {
+	data, err := NewFile(file.client, file.endpoint, file.config).Select("ETag,Length,TimeLastModified").Get()
+	if err != nil {
+		return nil, err
+	}
+	info := data.Data()
+	props, err := file.Props().Get()
+	if err != nil {
+		return nil, err
+	}
+	return &FileState{
+		ETag:             info.ETag,
+		Length:           int64(info.Length),
+		LengthKnown:      true,
+		TimeLastModified: info.TimeLastModified,
+		QuickXorHash:     getQuickXorHashProp(props.Data()),
+	}, nil
+}
+
+// Diff compares the known file state, e.g. a local copy or a previous sync state, with the server file
+func (file *File) Diff(known *FileState) (*FileDiff, error) {
+	remote, err := file.State()
+	if err != nil {
+		return nil, err
+	}
+	return DiffFileStates(known, remote), nil
+}
+
+// DiffFileStates compares file states, attributes empty in any of the states are not compared
+func DiffFileStates(known *FileState, remote *FileState) *FileDiff {
+	diff := &FileDiff{Remote: remote}
+	if known.ETag != "" && remote.ETag != "" {
+		diff.ETag = !sameETag(known.ETag, remote.ETag)
+	}
+	if known.LengthKnown && remote.LengthKnown {
+		diff.Length = known.Length != remote.Length
+	}
+	if !known.TimeLastModified.IsZero() && !remote.TimeLastModified.IsZero() {
+		diff.TimeLastModified = !known.TimeLastModified.Truncate(time.Second).Equal(remote.TimeLastModified.Truncate(time.Second))
+	}
+	knownHash := normalizeQuickXorHash(known.QuickXorHash)
+	remoteHash := normalizeQuickXorHash(remote.QuickXorHash)
+	if knownHash != "" && remoteHash != "" {
+		diff.HashCompared = true
+		diff.QuickXorHash = knownHash != remoteHash
+	}
+	return diff
+}
+
+// quickXorHashProps are known content hash property names in the order of preference
+var quickXorHashProps = []string{"vti_qxhash", "QuickXorHash"}
+
+// getQuickXorHashProp finds content hash in file properties, known names are checked first (see quickXorHashProps),
+// then the other names containing `quickxorhash` or `qxhash` in alphabetical order
+func getQuickXorHashProp(props map[string]string) string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, name := range quickXorHashProps {
+		for _, key := range keys {
+			if strings.EqualFold(key, name) {
+				return props[key]
+			}
+		}
+	}
+	for _, key := range keys {
+		k := strings.ToLower(key)
+		if strings.Contains(k, "quickxorhash") || strings.Contains(k, "qxhash") {
+			return props[key]
+		}
+	}
+	return ""
+}
+
+// normalizeQuickXorHash converts hex encoded hash to base64, SharePoint exposes the hash in both encodings
+func normalizeQuickXorHash(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) == hex.EncodedLen(QuickXorHashSize) {
+		if sum, err := hex.DecodeString(value); err == nil {
+			return base64.StdEncoding.EncodeToString(sum)
+		}
+	}
+	return value
+}
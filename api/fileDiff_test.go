@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestFileDiff(t *testing.T) {
+
+	t.Run("DiffFileStates", func(t *testing.T) {
+		now := time.Now()
+		known := &FileState{ETag: `"{A},1"`, Length: 10, LengthKnown: true, TimeLastModified: now}
+		remote := &FileState{ETag: `"{a},1"`, Length: 10, LengthKnown: true, TimeLastModified: now.Add(100 * time.Millisecond).Truncate(time.Second)}
+		if diff := DiffFileStates(known, remote); diff.Changed() {
+			t.Errorf("states should match: %+v", diff)
+		}
+		remote = &FileState{ETag: `"{A},2"`, Length: 11, LengthKnown: true, TimeLastModified: now.Add(time.Minute)}
+		diff := DiffFileStates(known, remote)
+		if !diff.Changed() || !diff.ETag || !diff.Length || !diff.TimeLastModified || diff.HashCompared {
+			t.Errorf("incorrect diff: %+v", diff)
+		}
+	})
+
+	t.Run("DiffUnknownLength", func(t *testing.T) {
+		empty := &FileState{Length: 0, LengthKnown: true}
+		remote := &FileState{Length: 10, LengthKnown: true}
+		if diff := DiffFileStates(empty, remote); !diff.Length {
+			t.Errorf("empty file length should have been compared: %+v", diff)
+		}
+		if diff := DiffFileStates(&FileState{ETag: `"{A},1"`}, remote); diff.Length {
+			t.Errorf("unknown length should not have been compared: %+v", diff)
+		}
+	})
+
+	t.Run("DiffHashes", func(t *testing.T) {
+		hash, _ := QuickXorHashOf(bytes.NewBufferString("content"))
+		known := &FileState{Length: 7, LengthKnown: true, QuickXorHash: hash}
+		remote := &FileState{ETag: `"{A},3"`, Length: 7, LengthKnown: true, TimeLastModified: time.Now(), QuickXorHash: hash}
+		if diff := DiffFileStates(known, remote); diff.Changed() || !diff.HashCompared {
+			t.Errorf("equal hashes should mean no changes: %+v", diff)
+		}
+		remote.QuickXorHash, _ = QuickXorHashOf(bytes.NewBufferString("CONTENT"))
+		if diff := DiffFileStates(known, remote); !diff.Changed() || !diff.QuickXorHash {
+			t.Errorf("different hashes should mean changes: %+v", diff)
+		}
+	})
+
+	t.Run("NormalizeQuickXorHash", func(t *testing.T) {
+		if h := normalizeQuickXorHash("4a00000000000000000000000100000000000000"); h != "SgAAAAAAAAAAAAAAAQAAAAAAAAA=" {
+			t.Errorf("incorrect hex hash conversion: %s", h)
+		}
+		if h := normalizeQuickXorHash("SgAAAAAAAAAAAAAAAQAAAAAAAAA="); h != "SgAAAAAAAAAAAAAAAQAAAAAAAAA=" {
+			t.Errorf("base64 hash should be kept: %s", h)
+		}
+	})
+
+	t.Run("QuickXorHashProp", func(t *testing.T) {
+		props := map[string]string{"vti_author": "me", "vti_qxhash": "hash"}
+		if h := getQuickXorHashProp(props); h != "hash" {
+			t.Errorf("incorrect hash property: %s", h)
+		}
+		props = map[string]string{"x_qxhash": "other", "QuickXorHash": "known", "a_quickxorhash": "other"}
+		for i := 0; i < 10; i++ {
+			if h := getQuickXorHashProp(props); h != "known" {
+				t.Fatalf("known hash property should have been preferred, got %s", h)
+			}
+		}
+		if h := getQuickXorHashProp(map[string]string{"x_qxhash": "x", "a_quickxorhash": "a"}); h != "a" {
+			t.Errorf("hash properties should have been checked in alphabetical order, got %s", h)
+		}
+		if h := getQuickXorHashProp(map[string]string{"vti_author": "me"}); h != "" {
+			t.Errorf("unexpected hash property: %s", h)
+		}
+	})
+
+	t.Run("NewLocalFileState", func(t *testing.T) {
+		localPath := filepath.Join(t.TempDir(), "file.txt")
+		if err := os.WriteFile(localPath, []byte("J"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		state, err := NewLocalFileState(localPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.Length != 1 || state.QuickXorHash != "SgAAAAAAAAAAAAAAAQAAAAAAAAA=" || state.TimeLastModified.IsZero() {
+			t.Errorf("incorrect local state: %+v", state)
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	newFolderName := uuid.New().String()
+	rootFolderURI := getRelativeURL(spClient.AuthCnfg.GetSiteURL()) + "/Shared%20Documents"
+	newFolderURI := rootFolderURI + "/" + newFolderName
+	if _, err := web.GetFolder(rootFolderURI).Folders().Add(newFolderName); err != nil {
+		t.Error(err)
+	}
+
+	content := []byte("file diff content")
+	fileResp, err := web.GetFolder(newFolderURI).Files().Add("diff.txt", content, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := web.GetFile(fileResp.Data().ServerRelativeURL)
+
+	t.Run("Diff", func(t *testing.T) {
+		state, err := file.State()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.Length != int64(len(content)) || state.ETag == "" {
+			t.Errorf("incorrect server state: %+v", state)
+		}
+		if envCode == "spo" && state.QuickXorHash != "" {
+			local, _ := QuickXorHashOf(bytes.NewReader(content))
+			if normalizeQuickXorHash(state.QuickXorHash) != local {
+				t.Errorf("server hash %s doesn't match local hash %s", state.QuickXorHash, local)
+			}
+		}
+
+		if _, err := web.GetFolder(newFolderURI).Files().Add("diff.txt", []byte("changed"), true); err != nil {
+			t.Fatal(err)
+		}
+		diff, err := file.Diff(state)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !diff.Changed() || !diff.ETag || !diff.Length {
+			t.Errorf("changes were not detected: %+v", diff)
+		}
+	})
+
+	if err := web.GetFolder(newFolderURI).Delete(); err != nil {
+		t.Error(err)
+	}
+
+}
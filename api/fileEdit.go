@@ -0,0 +1,158 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrEditConflict is returned by File.Edit when the file was modified by someone else while the edit was in progress
+var ErrEditConflict = errors.New("file was modified during the edit")
+
+// FileCheckedOutError is returned by File.Edit when the file is checked out by another user
+type FileCheckedOutError struct {
+	CheckedOutByUser *UserInfo // user holding the check out
+}
+
+// Error implements error interface
+func (e *FileCheckedOutError) Error() string {
+	return fmt.Sprintf("file is checked out by %s (%s)", e.CheckedOutByUser.Title, e.CheckedOutByUser.LoginName)
+}
+
+// FileEditOptions provides optional settings for File.Edit method
+type FileEditOptions struct {
+	CheckInComment string // check in comment
+	CheckInType    int    // check in type, see CheckInTypes, minor by default
+	SkipCheckOut   bool   // edit without check out and check in, the upload is still protected with ETag
+}
+
+// Edit edits the file content as a transaction: checks the file out, downloads the content,
+// applies the mutation, uploads the result only if the file was not modified since the download (ETag If-Match)
+// and checks the file in. The check out is undone when any of the steps fails.
+// Returns *FileCheckedOutError when the file is checked out by another user and ErrEditConflict on a concurrent modification.
+func (file *File) Edit(mutate func(content io.Reader) (io.Reader, error), options *FileEditOptions) error {
+	if options == nil {
+		options = &FileEditOptions{}
+	}
+
+	checkedOut := false
+	if !options.SkipCheckOut {
+		holder, byMe, err := file.checkOutState()
+		if err != nil {
+			return err
+		}
+		if holder != nil {
+			return &FileCheckedOutError{CheckedOutByUser: holder}
+		}
+		if !byMe {
+			if _, err := file.CheckOut(); err != nil {
+				// The file could have been checked out by another user in between
+				if holder, _, e := file.checkOutState(); e == nil && holder != nil {
+					return &FileCheckedOutError{CheckedOutByUser: holder}
+				}
+				return err
+			}
+			checkedOut = true
+		}
+	}
+
+	if err := file.editContent(mutate); err != nil {
+		if checkedOut {
+			_, _ = file.UndoCheckOut()
+		}
+		return err
+	}
+
+	if !options.SkipCheckOut {
+		if _, err := file.CheckIn(options.CheckInComment, options.CheckInType); err != nil {
+			if checkedOut {
+				_, _ = file.UndoCheckOut()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// checkOutState gets the user holding the file check out, nil when the file is not checked out or is checked out by the current user,
+// `byMe` is true when the file is checked out by the current user
+func (file *File) checkOutState() (holder *UserInfo, byMe bool, err error) {
+	data, err := NewFile(file.client, file.endpoint, file.config).
+		Select("CheckOutType,CheckedOutByUser/Id,CheckedOutByUser/Title,CheckedOutByUser/LoginName").
+		Expand("CheckedOutByUser").
+		Get()
+	if err != nil {
+		return nil, false, err
+	}
+	res := &struct {
+		CheckOutType     int       `json:"CheckOutType"`
+		CheckedOutByUser *UserInfo `json:"CheckedOutByUser"`
+	}{}
+	if err := json.Unmarshal(NormalizeODataItem(data), &res); err != nil {
+		return nil, false, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	if res.CheckOutType == 2 || res.CheckedOutByUser == nil || res.CheckedOutByUser.ID == 0 { // 2 - None
+		return nil, false, nil
+	}
+
+	web := NewWeb(file.client, getPriorEndpoint(file.endpoint, "/_api")+"/_api/Web", file.config)
+	user, err := web.CurrentUser().Select("Id").Get()
+	if err != nil {
+		return nil, false, err
+	}
+	if user.Data().ID == res.CheckedOutByUser.ID {
+		return nil, true, nil
+	}
+	return res.CheckedOutByUser, false, nil
+}
+
+// editContent downloads the content, applies the mutation and uploads the result with ETag check
+func (file *File) editContent(mutate func(content io.Reader) (io.Reader, error)) error {
+	resp, err := file.valueRequest("GET", nil, nil)
+	if err != nil {
+		if resp != nil {
+			shut(resp.Body)
+		}
+		return err
+	}
+	defer shut(resp.Body)
+	etag := resp.Header.Get("ETag")
+
+	content, err := mutate(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{"X-HTTP-Method": "PUT"}
+	if etag != "" {
+		headers["If-Match"] = etag
+	}
+	upResp, err := file.valueRequest("POST", content, headers)
+	if upResp != nil {
+		defer shut(upResp.Body)
+		if upResp.StatusCode == http.StatusPreconditionFailed {
+			return ErrEditConflict
+		}
+	}
+	return err
+}
+
+// valueRequest sends a request to the file content endpoint
+func (file *File) valueRequest(method string, body io.Reader, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/$value", file.endpoint), body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a request: %w", err)
+	}
+	if file.config != nil && file.config.Context != nil {
+		req = req.WithContext(file.config.Context)
+	}
+	for key, value := range getConfHeaders(file.config) {
+		req.Header.Set(key, value)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	return file.client.Execute(req)
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFileEdit(t *testing.T) {
+
+	t.Run("CheckedOutError", func(t *testing.T) {
+		var err error = &FileCheckedOutError{CheckedOutByUser: &UserInfo{Title: "John Doe", LoginName: "i:0#.f|membership|john@contoso.com"}}
+		var checkedOutErr *FileCheckedOutError
+		if !errors.As(err, &checkedOutErr) || checkedOutErr.CheckedOutByUser.Title != "John Doe" {
+			t.Error("typed error is expected")
+		}
+		if err.Error() != "file is checked out by John Doe (i:0#.f|membership|john@contoso.com)" {
+			t.Errorf("incorrect error message: %s", err)
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	newFolderName := uuid.New().String()
+	rootFolderURI := getRelativeURL(spClient.AuthCnfg.GetSiteURL()) + "/Shared%20Documents"
+	newFolderURI := rootFolderURI + "/" + newFolderName
+	if _, err := web.GetFolder(rootFolderURI).Folders().Add(newFolderName); err != nil {
+		t.Error(err)
+	}
+
+	fileResp, err := web.GetFolder(newFolderURI).Files().Add("edit.txt", []byte("Hello"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := web.GetFile(fileResp.Data().ServerRelativeURL)
+
+	t.Run("Edit", func(t *testing.T) {
+		err := file.Edit(func(content io.Reader) (io.Reader, error) {
+			data, err := ioutil.ReadAll(content)
+			if err != nil {
+				return nil, err
+			}
+			return bytes.NewReader(append(data, []byte(", World")...)), nil
+		}, &FileEditOptions{CheckInComment: "Gosip edit", CheckInType: CheckInTypes.Major})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := file.Download()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "Hello, World" {
+			t.Errorf("incorrect edited content: %s", data)
+		}
+		info, err := file.Select("CheckOutType").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Data().CheckOutType != 2 {
+			t.Error("file should be checked in")
+		}
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		mutationErr := errors.New("mutation failed")
+		err := file.Edit(func(content io.Reader) (io.Reader, error) {
+			return nil, mutationErr
+		}, nil)
+		if err != mutationErr {
+			t.Errorf("mutation error is expected, got %v", err)
+		}
+		info, err := file.Select("CheckOutType").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Data().CheckOutType != 2 {
+			t.Error("check out should be undone")
+		}
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		err := file.Edit(func(content io.Reader) (io.Reader, error) {
+			if _, err := web.GetFolder(newFolderURI).Files().Add("edit.txt", []byte("Concurrent"), true); err != nil {
+				return nil, err
+			}
+			return bytes.NewBufferString("Lost update"), nil
+		}, &FileEditOptions{SkipCheckOut: true})
+		if err != ErrEditConflict {
+			t.Errorf("edit conflict is expected, got %v", err)
+		}
+	})
+
+	if err := web.GetFolder(newFolderURI).Delete(); err != nil {
+		t.Error(err)
+	}
+
+}
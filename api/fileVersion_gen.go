@@ -0,0 +1,28 @@
+// Code generated by `ggen -ent FileVersion -conf -mods Select,Expand -helpers Normalized`; DO NOT EDIT.
+
+package api
+
+// Conf receives custom request config definition, e.g. custom headers, custom OData mod
+func (fileVersion *FileVersion) Conf(config *RequestConfig) *FileVersion {
+	fileVersion.config = config
+	return fileVersion
+}
+
+// Select adds $select OData modifier
+func (fileVersion *FileVersion) Select(oDataSelect string) *FileVersion {
+	fileVersion.modifiers.AddSelect(oDataSelect)
+	return fileVersion
+}
+
+// Expand adds $expand OData modifier
+func (fileVersion *FileVersion) Expand(oDataExpand string) *FileVersion {
+	fileVersion.modifiers.AddExpand(oDataExpand)
+	return fileVersion
+}
+
+/* Response helpers */
+
+// Normalized returns normalized body
+func (fileVersionResp *FileVersionResp) Normalized() []byte {
+	return NormalizeODataItem(*fileVersionResp)
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pnocera/gosip"
+)
+
+//go:generate ggen -ent FileVersions -item FileVersion -conf -coll -mods Select,Expand,Filter,Top,OrderBy -helpers Data,Normalized
+//go:generate ggen -ent FileVersion -conf -mods Select,Expand -helpers Normalized
+
+// FileVersions represent SharePoint File Versions API queryable collection struct
+// Always use NewFileVersions constructor instead of &FileVersions{}
+type FileVersions struct {
+	client    *gosip.SPClient
+	config    *RequestConfig
+	endpoint  string
+	modifiers *ODataMods
+}
+
+// FileVersionsResp - file versions response type with helper processor methods
+type FileVersionsResp []byte
+
+// NewFileVersions - FileVersions struct constructor function
+func NewFileVersions(client *gosip.SPClient, endpoint string, config *RequestConfig) *FileVersions {
+	return &FileVersions{
+		client:    client,
+		endpoint:  endpoint,
+		config:    config,
+		modifiers: NewODataMods(),
+	}
+}
+
+// ToURL gets endpoint with modificators raw URL
+func (fileVersions *FileVersions) ToURL() string {
+	return toURL(fileVersions.endpoint, fileVersions.modifiers)
+}
+
+// Get gets file versions collection response
+func (fileVersions *FileVersions) Get() (FileVersionsResp, error) {
+	client := NewHTTPClient(fileVersions.client)
+	return client.Get(fileVersions.ToURL(), fileVersions.config)
+}
+
+// GetByID gets a file version by its ID, e.g. 512 for 1.0
+func (fileVersions *FileVersions) GetByID(versionID int) *FileVersion {
+	return NewFileVersion(
+		fileVersions.client,
+		fmt.Sprintf("%s(%d)", fileVersions.endpoint, versionID),
+		fileVersions.config,
+	)
+}
+
+// GetByLabel gets a file version by its label, e.g. "1.0"
+func (fileVersions *FileVersions) GetByLabel(label string) (*FileVersion, error) {
+	scoped := NewFileVersions(fileVersions.client, fileVersions.endpoint, fileVersions.config)
+	data, err := scoped.Select("ID,VersionLabel").Get()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range data.Data() {
+		if info := v.Data(); info.Label == label {
+			return fileVersions.GetByID(info.ID), nil
+		}
+	}
+	return nil, fmt.Errorf("can't find version \"%s\"", label)
+}
+
+// RestoreByLabel restores a file version by its label, the restored content becomes a new version
+func (fileVersions *FileVersions) RestoreByLabel(label string) error {
+	client := NewHTTPClient(fileVersions.client)
+	endpoint := fmt.Sprintf("%s/RestoreByLabel(versionlabel='%s')", fileVersions.endpoint, escapeODataString(label))
+	_, err := client.Post(endpoint, nil, fileVersions.config)
+	return err
+}
+
+// DeleteByLabel deletes a file version by its label
+func (fileVersions *FileVersions) DeleteByLabel(label string) error {
+	client := NewHTTPClient(fileVersions.client)
+	endpoint := fmt.Sprintf("%s/DeleteByLabel(versionlabel='%s')", fileVersions.endpoint, escapeODataString(label))
+	_, err := client.Post(endpoint, nil, fileVersions.config)
+	return err
+}
+
+// DeleteByID deletes a file version by its ID
+func (fileVersions *FileVersions) DeleteByID(versionID int) error {
+	client := NewHTTPClient(fileVersions.client)
+	endpoint := fmt.Sprintf("%s/DeleteByID(vid=%d)", fileVersions.endpoint, versionID)
+	_, err := client.Post(endpoint, nil, fileVersions.config)
+	return err
+}
+
+// DeleteAll deletes all file versions except the current one
+func (fileVersions *FileVersions) DeleteAll() error {
+	client := NewHTTPClient(fileVersions.client)
+	endpoint := fmt.Sprintf("%s/DeleteAll", fileVersions.endpoint)
+	_, err := client.Post(endpoint, nil, fileVersions.config)
+	return err
+}
+
+/* File version */
+
+// FileVersion represents SharePoint File Version API queryable object struct
+// Always use NewFileVersion constructor instead of &FileVersion{}
+type FileVersion struct {
+	client    *gosip.SPClient
+	config    *RequestConfig
+	endpoint  string
+	modifiers *ODataMods
+}
+
+// FileVersionResp - file version response type with helper processor methods
+type FileVersionResp []byte
+
+// NewFileVersion - FileVersion struct constructor function
+func NewFileVersion(client *gosip.SPClient, endpoint string, config *RequestConfig) *FileVersion {
+	return &FileVersion{
+		client:    client,
+		endpoint:  endpoint,
+		config:    config,
+		modifiers: NewODataMods(),
+	}
+}
+
+// ToURL gets endpoint with modificators raw URL
+func (fileVersion *FileVersion) ToURL() string {
+	return toURL(fileVersion.endpoint, fileVersion.modifiers)
+}
+
+// Get gets file version data object
+func (fileVersion *FileVersion) Get() (FileVersionResp, error) {
+	client := NewHTTPClient(fileVersion.client)
+	return client.Get(fileVersion.ToURL(), fileVersion.config)
+}
+
+// GetReader gets file version content io.ReadCloser
+func (fileVersion *FileVersion) GetReader() (io.ReadCloser, error) {
+	endpoint := fmt.Sprintf("%s/$value", fileVersion.endpoint)
+
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply context
+	if fileVersion.config != nil && fileVersion.config.Context != nil {
+		req = req.WithContext(fileVersion.config.Context)
+	}
+
+	req.TransferEncoding = []string{"null"}
+	for key, value := range getConfHeaders(fileVersion.config) {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := fileVersion.client.Execute(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Download downloads file version content bytes
+func (fileVersion *FileVersion) Download() ([]byte, error) {
+	body, err := fileVersion.GetReader()
+	if err != nil {
+		return nil, err
+	}
+	defer shut(body)
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+/* Response helpers */
+
+// Data response helper
+func (fileVersionResp *FileVersionResp) Data() *VersionInfo {
+	return parseVersionInfo(*fileVersionResp)
+}
@@ -0,0 +1,90 @@
+// Code generated by `ggen -ent FileVersions -item FileVersion -conf -coll -mods Select,Expand,Filter,Top,OrderBy -helpers Data,Normalized`; DO NOT EDIT.
+
+package api
+
+// Conf receives custom request config definition, e.g. custom headers, custom OData mod
+func (fileVersions *FileVersions) Conf(config *RequestConfig) *FileVersions {
+	fileVersions.config = config
+	return fileVersions
+}
+
+// Select adds $select OData modifier
+func (fileVersions *FileVersions) Select(oDataSelect string) *FileVersions {
+	fileVersions.modifiers.AddSelect(oDataSelect)
+	return fileVersions
+}
+
+// Expand adds $expand OData modifier
+func (fileVersions *FileVersions) Expand(oDataExpand string) *FileVersions {
+	fileVersions.modifiers.AddExpand(oDataExpand)
+	return fileVersions
+}
+
+// Filter adds $filter OData modifier
+func (fileVersions *FileVersions) Filter(oDataFilter string) *FileVersions {
+	fileVersions.modifiers.AddFilter(oDataFilter)
+	return fileVersions
+}
+
+// Top adds $top OData modifier
+func (fileVersions *FileVersions) Top(oDataTop int) *FileVersions {
+	fileVersions.modifiers.AddTop(oDataTop)
+	return fileVersions
+}
+
+// OrderBy adds $orderby OData modifier
+func (fileVersions *FileVersions) OrderBy(oDataOrderBy string, ascending bool) *FileVersions {
+	fileVersions.modifiers.AddOrderBy(oDataOrderBy, ascending)
+	return fileVersions
+}
+
+/* Response helpers */
+
+// Data response helper
+func (fileVersionsResp *FileVersionsResp) Data() []FileVersionResp {
+	collection, _ := normalizeODataCollection(*fileVersionsResp)
+	fileVersions := []FileVersionResp{}
+	for _, item := range collection {
+		fileVersions = append(fileVersions, FileVersionResp(item))
+	}
+	return fileVersions
+}
+
+// Normalized returns normalized body
+func (fileVersionsResp *FileVersionsResp) Normalized() []byte {
+	normalized, _ := NormalizeODataCollection(*fileVersionsResp)
+	return normalized
+}
+
+/* Pagination helpers */
+
+// FileVersionsPager - FileVersions collection pages iterator
+type FileVersionsPager struct {
+	*Pager
+}
+
+// Pager gets FileVersions collection pages iterator, the iteration follows OData next page links
+func (fileVersions *FileVersions) Pager() *FileVersionsPager {
+	return &FileVersionsPager{NewPager(fileVersions.client, fileVersions.ToURL(), fileVersions.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *FileVersionsPager) Prefetch(enabled bool) *FileVersionsPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *FileVersionsPager) Page() FileVersionsResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (fileVersionsResp *FileVersionsResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*fileVersionsResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (fileVersionsResp *FileVersionsResp) HasNextPage() bool {
+	return fileVersionsResp.NextPageURL() != ""
+}
@@ -1,4 +1,4 @@
-// Code generated by `ggen -ent File -conf -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
+// Code generated by `ggen -ent File -conf -etag -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
 
 package api
 
@@ -38,3 +38,18 @@ func (fileResp *FileResp) Data() *FileInfo {
 func (fileResp *FileResp) Normalized() []byte {
 	return NormalizeODataItem(*fileResp)
 }
+
+/* Optimistic concurrency helpers */
+
+// IfMatch gets a copy of the entity with the expected ETag for Update and Delete requests,
+// the requests fail with *PreconditionFailedError when the entity was modified since the ETag was received
+func (file *File) IfMatch(etag string) *File {
+	scoped := *file
+	scoped.config = withIfMatch(file.config, etag)
+	return &scoped
+}
+
+// ETag gets entity ETag from the response metadata
+func (fileResp *FileResp) ETag() string {
+	return getODataETag(*fileResp)
+}
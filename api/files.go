@@ -57,3 +57,11 @@ func (files *Files) Add(name string, content []byte, overwrite bool) (FileResp,
 	endpoint := fmt.Sprintf("%s/Add(overwrite=%t,url='%s')", files.endpoint, overwrite, name)
 	return client.Post(endpoint, bytes.NewBuffer(content), files.config)
 }
+
+// AddUsingPath uploads file into the folder using ResourcePath API
+// Supported only in modern SharePoint, differs from Add with its capability of dealing with special chars in name (`'`, `#`, `%`)
+func (files *Files) AddUsingPath(name string, content []byte, overwrite bool) (FileResp, error) {
+	client := NewHTTPClient(files.client)
+	endpoint := fmt.Sprintf("%s/AddUsingPath(decodedUrl='%s',overwrite=%t)", files.endpoint, escapeResourcePath(name), overwrite)
+	return client.Post(endpoint, bytes.NewBuffer(content), files.config)
+}
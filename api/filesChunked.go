@@ -2,157 +2,455 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
-	"log"
+	"math"
 	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // AddChunkedOptions provides optional settings for AddChunked method
 type AddChunkedOptions struct {
-	Overwrite bool                                    // should overwrite existing file
-	Progress  func(data *FileUploadProgressData) bool // on progress callback, execute custom logic on each chunk, if the Progress is used it should return "true" to continue upload otherwise upload is canceled
-	ChunkSize int                                     // chunk size in bytes
+	Overwrite    bool                                    // should overwrite existing file
+	Progress     func(data *FileUploadProgressData) bool // on progress callback, execute custom logic on each chunk, if the Progress is used it should return "true" to continue upload otherwise upload is canceled
+	ChunkSize    int                                     // chunk size in bytes, the initial chunk size when AdaptiveChunkSize is used
+	SessionStore UploadSessionStore                      // persists upload session after each chunk, makes a failed upload resumable with ResumeChunked
+	Retries      int                                     // number of retries of a failed chunk, default is 3, negative value disables retries
+	Buffers      int                                     // number of chunk buffers, the next chunks are read while the current one is uploaded, default is 2
+	Hooks        *ChunkedUploadHooks                     // upload events handlers
+
+	AdaptiveChunkSize   bool          // adapt chunk size to observed throughput, chunks are sized to be uploaded in about TargetChunkDuration
+	MinChunkSize        int           // adaptive chunk size lower limit, default is a quarter of ChunkSize
+	MaxChunkSize        int           // adaptive chunk size upper limit, default is 8 times ChunkSize
+	TargetChunkDuration time.Duration // adaptive chunk upload duration goal, default is 5 seconds
 }
 
 // FileUploadProgressData describes Progress callback options
 type FileUploadProgressData struct {
 	UploadID    string
 	Stage       string
-	ChunkSize   int
-	BlockNumber int
-	FileOffset  int
+	ChunkSize   int     // current chunk size in bytes
+	BlockNumber int     // number of uploaded chunks
+	FileOffset  int     // number of uploaded bytes
+	Throughput  float64 // average upload throughput in bytes per second since the upload (or resume) start
+}
+
+// ChunkedUploadHooks struct to configure chunked upload events handlers
+type ChunkedUploadHooks struct {
+	OnChunkRead     func(event *ChunkedUploadEvent) // after a chunk is read from the stream
+	OnChunkUploaded func(event *ChunkedUploadEvent) // after a chunk is accepted by the server
+	OnRetry         func(event *ChunkedUploadEvent) // before a failed chunk upload is retried
+	OnCancel        func(event *ChunkedUploadEvent) // after the upload is canceled, Error is set when canceling failed
+}
+
+// ChunkedUploadEvent chunked upload hook event parameters struct
+type ChunkedUploadEvent struct {
+	UploadID  string
+	Stage     string        // "starting", "continue" or "finishing"
+	Offset    int           // file offset of the chunk
+	ChunkSize int           // chunk size in bytes
+	Duration  time.Duration // chunk upload duration, for OnChunkUploaded
+	Error     error         // failed attempt error, for OnRetry and OnCancel
 }
 
 // AddChunked uploads a file in chunks (streaming), is a good fit for large files. Supported starting from SharePoint 2016.
+// Reading the stream overlaps with uploading, chunks are uploaded sequentially as the chunk API requires.
+// When options.SessionStore is provided, the upload session is persisted after each chunk
+// and an interrupted upload can be continued with ResumeChunked.
 func (files *Files) AddChunked(name string, stream io.Reader, options *AddChunkedOptions) (FileResp, error) {
 	web := NewSP(files.client).Web().Conf(files.config)
-	var file *File
 	uploadID := uuid.New().String()
+	options = getAddChunkedOptions(options, 0)
 
-	cancelUpload := func(file *File, uploadID string) error {
-		if err := file.cancelUpload(uploadID); err != nil {
-			log.Printf("error canceling upload: %v", err)
-			return err
-		}
-		log.Printf("upload was canceled")
-		return fmt.Errorf("file upload was canceled")
+	pipeline := newChunkPipeline(stream, options)
+	defer pipeline.close()
+
+	first, err := pipeline.next(files.config)
+	if err != nil {
+		return nil, err
 	}
 
-	// Default props
-	if options == nil {
-		options = &AddChunkedOptions{
-			Overwrite: true,
-			ChunkSize: 10485760,
-		}
+	// Upload in a call if file size is less than chunk size
+	if first.last {
+		return files.Add(name, first.data, options.Overwrite)
 	}
-	if options.Progress == nil {
-		options.Progress = func(data *FileUploadProgressData) bool {
-			return true
-		}
+
+	// Initial chunked upload
+	progress := &FileUploadProgressData{
+		UploadID:  uploadID,
+		Stage:     "starting",
+		ChunkSize: len(first.data),
 	}
-	if options.ChunkSize == 0 {
-		options.ChunkSize = 10485760
+	if !options.Progress(progress) {
+		return nil, fmt.Errorf("file upload was canceled")
+	}
+	fileResp, err := files.Add(name, nil, options.Overwrite)
+	if err != nil {
+		return nil, err
 	}
 
-	progress := &FileUploadProgressData{
-		UploadID:    uploadID,
-		Stage:       "starting",
-		ChunkSize:   options.ChunkSize,
-		BlockNumber: 0,
-		FileOffset:  0,
+	session := &UploadSession{
+		UploadID:  uploadID,
+		FileURL:   fileResp.Data().ServerRelativeURL,
+		ChunkSize: options.ChunkSize,
 	}
+	file := web.GetFile(session.FileURL)
+	return file.uploadChunks(session, pipeline, first, sha256.New(), options)
+}
 
-	slot := make([]byte, options.ChunkSize)
-	//reader := bufio.NewReader(stream)
-	for {
+// ResumeChunked continues an interrupted chunked upload described by the session, e.g. loaded from UploadSessionStore.
+// The stream should provide the whole file content from the beginning, the already uploaded part is read
+// and verified against the session checksum before the upload continues from the server confirmed offset.
+func (files *Files) ResumeChunked(session *UploadSession, stream io.Reader, options *AddChunkedOptions) (FileResp, error) {
+	if session == nil || session.UploadID == "" || session.FileURL == "" {
+		return nil, fmt.Errorf("upload session is not provided")
+	}
+	web := NewSP(files.client).Web().Conf(files.config)
+	options = getAddChunkedOptions(options, session.ChunkSize)
 
-		numBytesRead := 0
-		for numBytesRead < options.ChunkSize {
-			n, err := stream.Read(slot[numBytesRead:])
-			if err != nil {
-				if err == io.EOF {
-					break
-				}
+	// Skipping already uploaded content
+	hash := sha256.New()
+	if _, err := io.CopyN(hash, stream, int64(session.Offset)); err != nil {
+		return nil, fmt.Errorf("unable to read uploaded content: %w", err)
+	}
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != session.Checksum {
+		return nil, fmt.Errorf("stream content doesn't match upload session checksum")
+	}
+
+	// Verifying server offset with an empty chunk
+	file := web.GetFile(session.FileURL)
+	offset, err := file.continueUpload(session.UploadID, session.Offset, nil)
+	if err != nil {
+		return nil, fmt.Errorf("upload session is not valid on the server: %w", err)
+	}
+	if offset != session.Offset {
+		return nil, fmt.Errorf("upload session offset %d doesn't match server offset %d", session.Offset, offset)
+	}
+
+	pipeline := newChunkPipeline(stream, options)
+	defer pipeline.close()
+	return file.uploadChunks(session, pipeline, nil, hash, options)
+}
+
+// uploadChunks uploads pipeline chunks starting from session offset, the upload is started when the offset is 0.
+// The `first` chunk, when provided, is uploaded before the pipeline chunks.
+func (file *File) uploadChunks(session *UploadSession, pipeline *chunkPipeline, first *pipelineChunk, hasher hash.Hash, options *AddChunkedOptions) (FileResp, error) {
+	hooks := options.Hooks
+	if hooks == nil {
+		hooks = &ChunkedUploadHooks{}
+	}
+
+	cancelUpload := func() error {
+		if options.SessionStore != nil {
+			_ = options.SessionStore.Delete(session.FileURL)
+		}
+		err := file.cancelUpload(session.UploadID)
+		if hooks.OnCancel != nil {
+			hooks.OnCancel(&ChunkedUploadEvent{UploadID: session.UploadID, Offset: session.Offset, Error: err})
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("file upload was canceled")
+	}
+
+	progress := &FileUploadProgressData{UploadID: session.UploadID}
+	startedAt := time.Now()
+	startOffset := session.Offset
+
+	for {
+		chunk := first
+		first = nil
+		if chunk == nil {
+			var err error
+			if chunk, err = pipeline.next(file.config); err != nil {
+				return nil, err
 			}
-			numBytesRead += n
 		}
 
-		// size, err := reader.Read(slot)
-		// if err == io.EOF {
-		// 	break
-		// }
-		chunk := slot[:numBytesRead]
+		event := &ChunkedUploadEvent{
+			UploadID:  session.UploadID,
+			Stage:     "continue",
+			Offset:    session.Offset,
+			ChunkSize: len(chunk.data),
+		}
+		if session.Offset == 0 {
+			event.Stage = "starting"
+		}
+		if chunk.last {
+			event.Stage = "finishing"
+		}
+		if hooks.OnChunkRead != nil {
+			hooks.OnChunkRead(event)
+		}
 
-		log.Printf("read chunk size: %d", numBytesRead)
+		progress.Stage = event.Stage
+		progress.ChunkSize = len(chunk.data)
+		progress.BlockNumber = session.BlockNumber
+		progress.FileOffset = session.Offset
+		if elapsed := time.Since(startedAt).Seconds(); elapsed > 0 {
+			progress.Throughput = float64(session.Offset-startOffset) / elapsed
+		}
 
-		// Upload in a call if file size is less than chunk size
-		// pnocera: this is not working with io.Reader when it's not a file but a reader from an io.Pipe, so I commented it out
-		if numBytesRead < options.ChunkSize && progress.BlockNumber == 0 {
-			return files.Add(name, chunk, options.Overwrite)
+		// The start progress is reported before the file is created
+		if event.Stage != "starting" && !options.Progress(progress) {
+			return nil, cancelUpload()
 		}
 
-		// Finishing uploading chunked file
-		if numBytesRead < options.ChunkSize && progress.BlockNumber > 0 {
-			progress.Stage = "finishing"
-			log.Printf("finishing upload")
-			if !options.Progress(progress) {
-				return nil, cancelUpload(file, uploadID)
+		onRetry := func(err error) {
+			if hooks.OnRetry != nil {
+				e := *event
+				e.Error = err
+				hooks.OnRetry(&e)
 			}
-			if file == nil {
-				return nil, fmt.Errorf("can't get file object")
-			}
-			return file.finishUpload(uploadID, progress.FileOffset, chunk)
 		}
 
-		// Initial chunked upload
-		if progress.BlockNumber == 0 {
-			progress.Stage = "starting"
-			log.Printf("starting upload")
-			if !options.Progress(progress) {
-				return nil, fmt.Errorf("file upload was canceled") // cancelUpload(file, uploadID)
-			}
-			fileResp, err := files.Add(name, nil, options.Overwrite)
+		chunkStartedAt := time.Now()
+
+		// Finishing uploading chunked file
+		if chunk.last {
+			var fileResp FileResp
+			err := retryChunk(file.config, options.Retries, onRetry, func() (err error) {
+				fileResp, err = file.finishUpload(session.UploadID, session.Offset, chunk.data)
+				return err
+			})
 			if err != nil {
-				return nil, err
+				return nil, file.chunkError(session, options, err)
 			}
-			file = web.GetFile(fileResp.Data().ServerRelativeURL)
-			offset, err := file.startUpload(uploadID, chunk)
-			if err != nil {
-				return nil, err
+			event.Duration = time.Since(chunkStartedAt)
+			if hooks.OnChunkUploaded != nil {
+				hooks.OnChunkUploaded(event)
 			}
-			progress.FileOffset = offset
-		} else { // or continue chunk upload
-			progress.Stage = "continue"
-			log.Printf("continue upload")
-			if !options.Progress(progress) {
-				return nil, cancelUpload(file, uploadID)
+			if options.SessionStore != nil {
+				_ = options.SessionStore.Delete(session.FileURL)
 			}
-			if file == nil {
-				return nil, fmt.Errorf("can't get file object")
+			return fileResp, nil
+		}
+
+		offset, err := file.uploadChunk(session, chunk.data, options.Retries, onRetry)
+		if err != nil {
+			return nil, file.chunkError(session, options, err)
+		}
+		event.Duration = time.Since(chunkStartedAt)
+		if hooks.OnChunkUploaded != nil {
+			hooks.OnChunkUploaded(event)
+		}
+		if options.AdaptiveChunkSize {
+			pipeline.adapt(len(chunk.data), event.Duration)
+		}
+
+		_, _ = hasher.Write(chunk.data)
+		pipeline.release(chunk)
+		session.Offset = offset
+		session.Checksum = hex.EncodeToString(hasher.Sum(nil))
+		session.BlockNumber++
+		if options.SessionStore != nil {
+			if err := options.SessionStore.Save(session); err != nil {
+				return nil, fmt.Errorf("unable to save upload session: %w", err)
 			}
-			offset, err := file.continueUpload(uploadID, progress.FileOffset, chunk)
-			if err != nil {
-				return nil, err
+		}
+	}
+}
+
+// uploadChunk starts or continues the upload with a chunk retrying transient failures,
+// before a retry checks if the chunk was accepted by the server with the failed attempt
+func (file *File) uploadChunk(session *UploadSession, chunk []byte, retries int, onRetry func(err error)) (int, error) {
+	var offset int
+	attempt := 0
+	err := retryChunk(file.config, retries, onRetry, func() (err error) {
+		if attempt > 0 && session.Offset > 0 {
+			next := session.Offset + len(chunk)
+			if o, err := file.continueUpload(session.UploadID, next, nil); err == nil && o == next {
+				offset = o
+				return nil
 			}
-			progress.FileOffset = offset
 		}
+		attempt++
+		if session.Offset == 0 {
+			offset, err = file.startUpload(session.UploadID, chunk)
+			return err
+		}
+		offset, err = file.continueUpload(session.UploadID, session.Offset, chunk)
+		return err
+	})
+	return offset, err
+}
 
-		progress.BlockNumber++
+// chunkError wraps failed chunk error, the upload is kept on the server when it can be resumed
+func (file *File) chunkError(session *UploadSession, options *AddChunkedOptions, err error) error {
+	if options.SessionStore != nil && session.Offset > 0 {
+		return fmt.Errorf("chunk upload failed at offset %d, the upload can be resumed: %w", session.Offset, err)
 	}
+	return err
+}
 
-	progress.Stage = "finishing"
-	log.Printf("finishing upload on main")
-	if !options.Progress(progress) {
-		return nil, cancelUpload(file, uploadID)
+// retryChunk calls `fn` until it succeeds or retries are exhausted, waits with exponential backoff between attempts
+func retryChunk(config *RequestConfig, retries int, onRetry func(err error), fn func() error) error {
+	ctx := context.Background()
+	if config != nil && config.Context != nil {
+		ctx = config.Context
+	}
+	var err error
+	for retry := 0; ; retry++ {
+		if err = fn(); err == nil || retry >= retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(200*math.Pow(2, float64(retry))) * time.Millisecond):
+		}
+		if onRetry != nil {
+			onRetry(err)
+		}
+	}
+}
+
+// readChunk reads stream until the slot is full or the stream ends, unlike a single Read
+// it doesn't treat short reads (e.g. from io.Pipe or network streams) as the end of the stream
+func readChunk(stream io.Reader, slot []byte) (int, error) {
+	n, err := io.ReadFull(stream, slot)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return n, nil
+	}
+	return n, err
+}
+
+// getAddChunkedOptions applies default chunked upload options
+func getAddChunkedOptions(options *AddChunkedOptions, chunkSize int) *AddChunkedOptions {
+	opts := AddChunkedOptions{Overwrite: true}
+	if options != nil {
+		opts = *options
+	}
+	if opts.Progress == nil {
+		opts.Progress = func(data *FileUploadProgressData) bool {
+			return true
+		}
+	}
+	if opts.ChunkSize == 0 {
+		opts.ChunkSize = chunkSize
+	}
+	if opts.ChunkSize == 0 {
+		opts.ChunkSize = 10485760
 	}
-	if file == nil {
-		return nil, fmt.Errorf("can't get file object")
+	if opts.Retries == 0 {
+		opts.Retries = 3
 	}
-	return file.finishUpload(uploadID, progress.FileOffset, nil)
+	if opts.Buffers <= 0 {
+		opts.Buffers = 2
+	}
+	if opts.MinChunkSize <= 0 {
+		opts.MinChunkSize = int(math.Max(1, float64(opts.ChunkSize/4)))
+	}
+	if opts.MaxChunkSize <= 0 {
+		opts.MaxChunkSize = opts.ChunkSize * 8
+	}
+	if opts.TargetChunkDuration <= 0 {
+		opts.TargetChunkDuration = 5 * time.Second
+	}
+	return &opts
+}
+
+// chunkPipeline reads stream chunks ahead of the upload using a bounded buffers pool
+type chunkPipeline struct {
+	chunks    chan *pipelineChunk
+	free      chan []byte
+	done      chan struct{}
+	chunkSize int64 // current chunk size, accessed atomically
+	options   *AddChunkedOptions
+}
+
+// pipelineChunk - chunk read by the pipeline, `last` chunk is shorter than requested and ends the stream
+type pipelineChunk struct {
+	data []byte
+	last bool
+	err  error
+}
+
+// newChunkPipeline creates chunks pipeline and starts reading the stream
+func newChunkPipeline(stream io.Reader, options *AddChunkedOptions) *chunkPipeline {
+	p := &chunkPipeline{
+		chunks:    make(chan *pipelineChunk, options.Buffers),
+		free:      make(chan []byte, options.Buffers),
+		done:      make(chan struct{}),
+		chunkSize: int64(options.ChunkSize),
+		options:   options,
+	}
+	for i := 0; i < options.Buffers; i++ {
+		p.free <- nil // buffers are allocated on demand
+	}
+	go p.read(stream)
+	return p
+}
+
+// read reads the stream to pooled buffers until the stream ends, fails or the pipeline is closed
+func (p *chunkPipeline) read(stream io.Reader) {
+	for {
+		var buf []byte
+		select {
+		case buf = <-p.free:
+		case <-p.done:
+			return
+		}
+		size := int(atomic.LoadInt64(&p.chunkSize))
+		if cap(buf) < size {
+			buf = make([]byte, size)
+		}
+		n, err := readChunk(stream, buf[:size])
+		chunk := &pipelineChunk{data: buf[:n], last: n < size, err: err}
+		select {
+		case p.chunks <- chunk:
+		case <-p.done:
+			return
+		}
+		if chunk.last || err != nil {
+			return
+		}
+	}
+}
+
+// next gets the next chunk, fails on stream read error or context cancellation
+func (p *chunkPipeline) next(config *RequestConfig) (*pipelineChunk, error) {
+	ctx := context.Background()
+	if config != nil && config.Context != nil {
+		ctx = config.Context
+	}
+	select {
+	case chunk := <-p.chunks:
+		return chunk, chunk.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// release returns the chunk buffer to the pool
+func (p *chunkPipeline) release(chunk *pipelineChunk) {
+	p.free <- chunk.data[:0]
+}
+
+// adapt adjusts the next chunks size to the observed chunk upload throughput
+func (p *chunkPipeline) adapt(size int, duration time.Duration) {
+	if duration <= 0 {
+		return
+	}
+	target := float64(size) * float64(p.options.TargetChunkDuration) / float64(duration)
+	// smoothing the change to not overreact on a single slow or fast chunk
+	target = math.Max(math.Min(target, float64(size)*2), float64(size)/2)
+	target = math.Max(math.Min(target, float64(p.options.MaxChunkSize)), float64(p.options.MinChunkSize))
+	atomic.StoreInt64(&p.chunkSize, int64(target))
+}
+
+// close stops the stream reading
+func (p *chunkPipeline) close() {
+	close(p.done)
 }
 
 // startUpload starts uploading a document using chunk API
@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 
@@ -36,14 +37,21 @@ func TestFilesChunked(t *testing.T) {
 		fileName := fmt.Sprintf("ChunkedFile.txt")
 		content := "Greater than a chunk content..."
 		stream := strings.NewReader(content)
+		uploaded := 0
 		options := &AddChunkedOptions{
 			Overwrite: true,
 			ChunkSize: 5,
+			Hooks: &ChunkedUploadHooks{
+				OnChunkUploaded: func(event *ChunkedUploadEvent) { uploaded += event.ChunkSize },
+			},
 		}
 		fileResp, err := web.GetFolder(newFolderURI).Files().AddChunked(fileName, stream, options)
 		if err != nil {
 			t.Error(err)
 		}
+		if uploaded != len(content) {
+			t.Errorf("incorrect uploaded bytes reported by hooks: %d", uploaded)
+		}
 		data, err := web.GetFile(fileResp.Data().ServerRelativeURL).Download()
 		if err != nil {
 			t.Error(err)
@@ -149,7 +157,67 @@ func TestFilesChunked(t *testing.T) {
 		}
 	})
 
+	t.Run("ResumeChunked", func(t *testing.T) {
+		fileName := "ResumedFile.txt"
+		content := "Greater than a chunk content, interrupted and resumed..."
+		store := NewMemoryUploadSessionStore()
+		options := &AddChunkedOptions{
+			Overwrite:    true,
+			ChunkSize:    5,
+			SessionStore: store,
+			Retries:      -1,
+		}
+		stream := &failingReader{r: strings.NewReader(content), failAt: 12}
+		if _, err := web.GetFolder(newFolderURI).Files().AddChunked(fileName, stream, options); err == nil {
+			t.Fatal("upload should be interrupted")
+		}
+		fileURL := strings.Replace(newFolderURI, "%20", " ", -1) + "/" + fileName
+		session, err := store.Load(fileURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if session == nil || session.Offset != 10 {
+			t.Fatalf("incorrect upload session: %+v", session)
+		}
+		if _, err := web.GetFolder(newFolderURI).Files().ResumeChunked(session, strings.NewReader("x"+content), options); err == nil {
+			t.Error("should verify content checksum")
+		}
+		fileResp, err := web.GetFolder(newFolderURI).Files().ResumeChunked(session, strings.NewReader(content), options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := web.GetFile(fileResp.Data().ServerRelativeURL).Download()
+		if err != nil {
+			t.Error(err)
+		}
+		if !bytes.Equal([]byte(content), data) {
+			t.Error("wrong file content after resumed upload")
+		}
+		if session, _ := store.Load(fileURL); session != nil {
+			t.Error("session should be deleted after the upload")
+		}
+	})
+
 	if err := web.GetFolder(newFolderURI).Delete(); err != nil {
 		t.Error(err)
 	}
 }
+
+// failingReader fails reading after `failAt` bytes to simulate interrupted uploads
+type failingReader struct {
+	r      io.Reader
+	read   int
+	failAt int
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if f.read >= f.failAt {
+		return 0, fmt.Errorf("stream is interrupted")
+	}
+	if len(p) > f.failAt-f.read {
+		p = p[:f.failAt-f.read]
+	}
+	n, err := f.r.Read(p)
+	f.read += n
+	return n, err
+}
@@ -55,3 +55,36 @@ func (filesResp *FilesResp) Normalized() []byte {
 	normalized, _ := NormalizeODataCollection(*filesResp)
 	return normalized
 }
+
+/* Pagination helpers */
+
+// FilesPager - Files collection pages iterator
+type FilesPager struct {
+	*Pager
+}
+
+// Pager gets Files collection pages iterator, the iteration follows OData next page links
+func (files *Files) Pager() *FilesPager {
+	return &FilesPager{NewPager(files.client, files.ToURL(), files.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *FilesPager) Prefetch(enabled bool) *FilesPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *FilesPager) Page() FilesResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (filesResp *FilesResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*filesResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (filesResp *FilesResp) HasNextPage() bool {
+	return filesResp.NextPageURL() != ""
+}
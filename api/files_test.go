@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -90,6 +91,39 @@ func TestFiles(t *testing.T) {
 		}
 	})
 
+	t.Run("UsingPath", func(t *testing.T) {
+		if envCode != "spo" {
+			t.Skip("is not supported with legacy SP")
+		}
+
+		decodedFolderURI := strings.Replace(newFolderURI, "%20", " ", -1)
+		for _, name := range []string{"O'Brien #1 100%.txt", "what? %20.txt"} {
+			if _, err := web.GetFolderByPath(newFolderURI).Files().AddUsingPath(name, []byte(name), true); err != nil {
+				t.Error(err)
+				continue
+			}
+			// GetFileByPath takes the path as is, special chars are escaped by the caller
+			file := web.GetFileByPath(newFolderURI + "/" + escapeResourcePath(name))
+			data, err := file.Select("Name").Get()
+			if err != nil {
+				t.Error(err)
+				continue
+			}
+			if data.Data().Name != name {
+				t.Errorf("incorrect file name, expected `%s`, got `%s`", name, data.Data().Name)
+			}
+			if _, err := file.CopyToUsingPath(decodedFolderURI+"/Copy of "+name, true); err != nil {
+				t.Error(err)
+			}
+			if _, err := file.MoveToUsingPath(decodedFolderURI+"/Moved "+name, true); err != nil {
+				t.Error(err)
+			}
+			if _, err := web.GetFileByPath(newFolderURI + "/Moved " + escapeResourcePath(name)).Get(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
 	if err := web.GetFolder(newFolderURI).Delete(); err != nil {
 		t.Error(err)
 	}
@@ -11,7 +11,7 @@ import (
 	"github.com/pnocera/gosip"
 )
 
-//go:generate ggen -ent Folder -conf -mods Select,Expand -helpers Data,Normalized
+//go:generate ggen -ent Folder -conf -etag -mods Select,Expand -helpers Data,Normalized
 
 // Folder represents SharePoint Lists & Document Libraries Folder API queryable object struct
 // Always use NewFolder constructor instead of &Folder{}
@@ -168,11 +168,112 @@ func (folder *Folder) GetItem() (*Item, error) {
 	return item, nil
 }
 
+// GetUserEffectivePermissions gets effective permissions of the user on this Folder list item
+func (folder *Folder) GetUserEffectivePermissions(loginName string) (*BasePermissions, error) {
+	return getUserEffectivePermissions(folder.client, fmt.Sprintf("%s/ListItemAllFields", folder.endpoint), loginName, folder.config)
+}
+
+// EffectiveBasePermissions gets effective permissions of the current user on this Folder list item
+func (folder *Folder) EffectiveBasePermissions() (*BasePermissions, error) {
+	return getEffectiveBasePermissions(folder.client, fmt.Sprintf("%s/ListItemAllFields", folder.endpoint), folder.config)
+}
+
 // ContextInfo gets current Context Info object data
 func (folder *Folder) ContextInfo() (*ContextInfo, error) {
 	return NewContext(folder.client, folder.ToURL(), folder.config).Get()
 }
 
+// MoveCopyOptions provides optional settings for folder MoveToWithOptions and CopyToWithOptions methods
+type MoveCopyOptions struct {
+	KeepBoth                      bool // rename the target on a name conflict instead of failing
+	ShouldBypassSharedLocks       bool // move or copy files locked by co-authoring
+	ResetAuthorAndCreatedOnCopy   bool // set the current user and time as author and created date of the copy
+	RetainEditorAndModifiedOnMove bool // keep editor and modified date of the moved items
+}
+
+// MoveToUsingPath moves folder to new location within the same site using ResourcePath based SP.MoveCopyUtil API,
+// `newURL` is a decoded server relative URL of the folder, with `overwrite` an existing folder is moved to the recycle bin first
+// Supported only in SharePoint Online, names with special chars in path (`'`, `#`, `%`) are supported
+func (folder *Folder) MoveToUsingPath(newURL string, overwrite bool) ([]byte, error) {
+	if err := folder.recycleTarget(newURL, overwrite); err != nil {
+		return nil, err
+	}
+	return folder.moveCopy("MoveFolderByPath", newURL, nil)
+}
+
+// CopyToUsingPath copies folder with its content to new location within the same site using ResourcePath based SP.MoveCopyUtil API,
+// `newURL` is a decoded server relative URL of the folder copy, with `overwrite` an existing folder is moved to the recycle bin first
+// Supported only in SharePoint Online, names with special chars in path (`'`, `#`, `%`) are supported
+func (folder *Folder) CopyToUsingPath(newURL string, overwrite bool) ([]byte, error) {
+	if err := folder.recycleTarget(newURL, overwrite); err != nil {
+		return nil, err
+	}
+	return folder.moveCopy("CopyFolderByPath", newURL, nil)
+}
+
+// MoveToWithOptions moves this folder to a new location using ResourcePath based SP.MoveCopyUtil API,
+// `destURL` is a server relative or absolute URL of the new folder, it can be in another site of the tenant.
+// Unlike URL based methods names with `#` and `%` are supported. Supported only in SharePoint Online.
+func (folder *Folder) MoveToWithOptions(destURL string, options *MoveCopyOptions) error {
+	_, err := folder.moveCopy("MoveFolderByPath", destURL, options)
+	return err
+}
+
+// CopyToWithOptions copies this folder with its content to a new location using ResourcePath based SP.MoveCopyUtil API,
+// see MoveToWithOptions for details. Supported only in SharePoint Online.
+func (folder *Folder) CopyToWithOptions(destURL string, options *MoveCopyOptions) error {
+	_, err := folder.moveCopy("CopyFolderByPath", destURL, options)
+	return err
+}
+
+// recycleTarget moves existing folder with the decoded server relative URL to the recycle bin when `overwrite` is set
+func (folder *Folder) recycleTarget(newURL string, overwrite bool) error {
+	if !overwrite {
+		return nil
+	}
+	web := NewWeb(folder.client, getPriorEndpoint(folder.endpoint, "/_api")+"/_api/Web", folder.config)
+	target := web.GetFolderByPath(escapeResourcePath(newURL))
+	data, err := target.Select("Exists").Get()
+	if err != nil || !data.Data().Exists {
+		return nil // nothing to overwrite
+	}
+	return target.Recycle()
+}
+
+// moveCopy calls SP.MoveCopyUtil folder method
+func (folder *Folder) moveCopy(method string, destURL string, options *MoveCopyOptions) ([]byte, error) {
+	if options == nil {
+		options = &MoveCopyOptions{}
+	}
+	srcURL, err := folder.serverRelativeURL()
+	if err != nil {
+		return nil, err
+	}
+	webURL := getPriorEndpoint(folder.endpoint, "/_api")
+	resourcePath := func(u string) map[string]interface{} {
+		return map[string]interface{}{
+			"__metadata": map[string]string{"type": "SP.ResourcePath"},
+			"DecodedUrl": toAbsoluteURL(webURL, u),
+		}
+	}
+	payload := map[string]interface{}{
+		"srcPath":  resourcePath(srcURL),
+		"destPath": resourcePath(destURL),
+		"options": map[string]interface{}{
+			"__metadata":                    map[string]string{"type": "SP.MoveCopyOptions"},
+			"KeepBoth":                      options.KeepBoth,
+			"ShouldBypassSharedLocks":       options.ShouldBypassSharedLocks,
+			"ResetAuthorAndCreatedOnCopy":   options.ResetAuthorAndCreatedOnCopy,
+			"RetainEditorAndModifiedOnMove": options.RetainEditorAndModifiedOnMove,
+		},
+	}
+	body, _ := json.Marshal(payload)
+
+	endpoint := fmt.Sprintf("%s/_api/SP.MoveCopyUtil.%s()", getPriorEndpoint(folder.endpoint, "/_api"), method)
+	client := NewHTTPClient(folder.client)
+	return client.Post(endpoint, bytes.NewBuffer(body), patchConfigHeaders(folder.config, HeadersPresets.Verbose.Headers))
+}
+
 // ToDo:
 // StorageMetrics
 
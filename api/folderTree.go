@@ -0,0 +1,436 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TreeSyncOptions provides optional settings for DownloadTree and UploadTree methods.
+// Glob patterns are matched against slash separated paths relative to the tree root, e.g. `docs/*.pdf`,
+// patterns without slashes match file or folder names at any level, e.g. `*.tmp`,
+// and `dir/**` patterns match everything inside the folder.
+type TreeSyncOptions struct {
+	Mode        string   // existing files handling, one of TreeSyncModes, default is Overwrite
+	Include     []string // glob patterns of files to transfer, all files are transferred when empty
+	Exclude     []string // glob patterns of files and folders to skip
+	Concurrency int      // number of files transferred in parallel, default is 5
+	DryRun      bool     // only plan operations without transferring files and creating folders
+	ChunkSize   int      // upload chunk size, see AddChunkedOptions
+}
+
+type treeSyncModes struct {
+	Overwrite string
+	Skip      string
+	Newer     string
+}
+
+// TreeSyncModes - available existing files handling modes
+var TreeSyncModes = func() *treeSyncModes {
+	return &treeSyncModes{
+		Overwrite: "overwrite", // always transfer files
+		Skip:      "skip",      // transfer only missing files
+		Newer:     "newer",     // transfer missing files and files with a newer modification time or different length
+	}
+}()
+
+// TreeSyncReport describes tree transfer operations, a plan when in dry-run mode
+type TreeSyncReport struct {
+	Operations []*TreeSyncOperation // operations ordered by path
+	Failed     int                  // number of failed operations
+}
+
+// TreeSyncOperation describes a single tree transfer operation
+type TreeSyncOperation struct {
+	Action string // "mkdir", "download", "upload" or "skip"
+	Path   string // slash separated path relative to the tree root
+	Source string // source local path or server relative URL
+	Target string // target local path or server relative URL
+	Size   int64  // file size in bytes
+	Reason string // why the operation was planned, e.g. "missing", "newer", "exists"
+	Error  error  // operation error, nil on success or in dry-run mode
+}
+
+// treeFile - remote or local file metadata used for transfer decisions
+type treeFile struct {
+	path     string // relative path
+	url      string // server relative URL or local path
+	size     int64
+	modified time.Time
+}
+
+// DownloadTree downloads this folder files and subfolders to a local directory.
+// Files are transferred in parallel and downloaded files get the server modification time.
+// Supported only in modern SharePoint, files are addressed by path to deal with special chars in names.
+func (folder *Folder) DownloadTree(localDir string, options *TreeSyncOptions) (*TreeSyncReport, error) {
+	options = getTreeSyncOptions(options)
+	rootURL, err := folder.serverRelativeURL()
+	if err != nil {
+		return nil, err
+	}
+	web := NewSP(folder.client).Web().Conf(folder.config)
+
+	folders, files, err := folder.walkTree(rootURL, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []*TreeSyncOperation
+	for _, dir := range folders {
+		target := filepath.Join(localDir, filepath.FromSlash(dir))
+		if _, err := os.Stat(target); os.IsNotExist(err) {
+			ops = append(ops, &TreeSyncOperation{Action: "mkdir", Path: dir, Source: rootURL + "/" + dir, Target: target, Reason: "missing"})
+		}
+	}
+	for _, file := range files {
+		op := &TreeSyncOperation{
+			Action: "download",
+			Path:   file.path,
+			Source: file.url,
+			Target: filepath.Join(localDir, filepath.FromSlash(file.path)),
+			Size:   file.size,
+		}
+		var local *treeFile
+		if stat, err := os.Stat(op.Target); err == nil {
+			local = &treeFile{size: stat.Size(), modified: stat.ModTime()}
+		}
+		op.Action, op.Reason = treeSyncAction(op.Action, file, local, options.Mode)
+		ops = append(ops, op)
+	}
+
+	report := &TreeSyncReport{Operations: ops}
+	if options.DryRun {
+		return report.complete(), nil
+	}
+
+	if err := os.MkdirAll(localDir, 0755); err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		if op.Action == "mkdir" {
+			op.Error = os.MkdirAll(op.Target, 0755)
+		}
+	}
+	modified := make(map[string]time.Time, len(files))
+	for _, file := range files {
+		modified[file.url] = file.modified
+	}
+	runTreeOperations(ops, "download", options.Concurrency, func(op *TreeSyncOperation) error {
+		return downloadTreeFile(web, op, modified[op.Source])
+	})
+
+	return report.complete(), nil
+}
+
+// UploadTree uploads a local directory files and subdirectories to this folder, missing folders are created.
+// Files are uploaded in parallel with AddChunked.
+// Supported only in modern SharePoint, folders are addressed by path to deal with special chars in names.
+func (folder *Folder) UploadTree(localDir string, options *TreeSyncOptions) (*TreeSyncReport, error) {
+	options = getTreeSyncOptions(options)
+	rootURL, err := folder.serverRelativeURL()
+	if err != nil {
+		return nil, err
+	}
+	web := NewSP(folder.client).Web().Conf(folder.config)
+
+	_, remoteFiles, err := folder.walkTree(rootURL, &TreeSyncOptions{})
+	if err != nil {
+		return nil, err
+	}
+	remote := map[string]*treeFile{}
+	for _, file := range remoteFiles {
+		remote[strings.ToLower(file.path)] = file
+	}
+	remoteFolders := map[string]bool{}
+	for _, file := range remoteFiles {
+		remoteFolders[strings.ToLower(path.Dir(file.path))] = true
+	}
+
+	var ops []*TreeSyncOperation
+	err = filepath.Walk(localDir, func(localPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(localDir, localPath)
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+		if info.IsDir() {
+			if matchTreeGlobs(options.Exclude, rel) {
+				return filepath.SkipDir
+			}
+			ops = append(ops, &TreeSyncOperation{Action: "mkdir", Path: rel, Source: localPath, Target: rootURL + "/" + rel, Reason: "missing"})
+			return nil
+		}
+		if !treeFileIncluded(rel, options) {
+			return nil
+		}
+		op := &TreeSyncOperation{
+			Action: "upload",
+			Path:   rel,
+			Source: localPath,
+			Target: rootURL + "/" + rel,
+			Size:   info.Size(),
+		}
+		local := &treeFile{path: rel, url: localPath, size: info.Size(), modified: info.ModTime()}
+		op.Action, op.Reason = treeSyncAction(op.Action, local, remote[strings.ToLower(rel)], options.Mode)
+		ops = append(ops, op)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Existing folders are not reported, a folder with files exists for sure
+	var planned []*TreeSyncOperation
+	for _, op := range ops {
+		if op.Action == "mkdir" && remoteFolders[strings.ToLower(op.Path)] {
+			continue
+		}
+		planned = append(planned, op)
+	}
+
+	report := &TreeSyncReport{Operations: planned}
+	if options.DryRun {
+		return report.complete(), nil
+	}
+
+	for _, op := range planned {
+		if op.Action == "mkdir" {
+			_, op.Error = web.EnsureFolder(op.Target)
+		}
+	}
+	runTreeOperations(planned, "upload", options.Concurrency, func(op *TreeSyncOperation) error {
+		return uploadTreeFile(web, op, options)
+	})
+
+	return report.complete(), nil
+}
+
+// serverRelativeURL gets this folder server relative URL
+func (folder *Folder) serverRelativeURL() (string, error) {
+	data, err := NewFolder(folder.client, folder.endpoint, folder.config).Select("ServerRelativeUrl").Get()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(data.Data().ServerRelativeURL, "/"), nil
+}
+
+// walkTree lists the folder tree subfolders and files matching the filters, paths are relative to rootURL
+func (folder *Folder) walkTree(rootURL string, options *TreeSyncOptions) ([]string, []*treeFile, error) {
+	var folders []string
+	var files []*treeFile
+	relPath := func(url string) string {
+		return strings.TrimPrefix(strings.TrimPrefix(url, rootURL), "/")
+	}
+
+	pager := folder.Files().Select("Name,ServerRelativeUrl,Length,TimeLastModified").Top(5000).Pager()
+	for pager.Next() {
+		page := pager.Page()
+		for _, f := range page.Data() {
+			info := f.Data()
+			rel := relPath(info.ServerRelativeURL)
+			if !treeFileIncluded(rel, options) {
+				continue
+			}
+			files = append(files, &treeFile{
+				path:     rel,
+				url:      info.ServerRelativeURL,
+				size:     int64(info.Length),
+				modified: info.TimeLastModified,
+			})
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	subFolders := folder.Folders().Select("Name,ServerRelativeUrl").Top(5000).Pager()
+	for subFolders.Next() {
+		page := subFolders.Page()
+		for _, f := range page.Data() {
+			info := f.Data()
+			rel := relPath(info.ServerRelativeURL)
+			if rel == "Forms" || matchTreeGlobs(options.Exclude, rel) {
+				continue // document library system folder or excluded subtree
+			}
+			folders = append(folders, rel)
+			sub := NewSP(folder.client).Web().Conf(folder.config).GetFolderByPath(escapeResourcePath(info.ServerRelativeURL))
+			subFoldersList, subFiles, err := sub.walkTree(rootURL, options)
+			if err != nil {
+				return nil, nil, err
+			}
+			folders = append(folders, subFoldersList...)
+			files = append(files, subFiles...)
+		}
+	}
+	if err := subFolders.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return folders, files, nil
+}
+
+// downloadTreeFile downloads a file to a temporary local file and replaces the target with it,
+// the target gets the server modification time
+func downloadTreeFile(web *Web, op *TreeSyncOperation, modified time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(op.Target), 0755); err != nil {
+		return err
+	}
+	tmpPath := op.Target + ".part"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	if _, err := web.GetFileByPath(escapeResourcePath(op.Source)).DownloadTo(f, nil); err != nil {
+		shut(f)
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, op.Target); err != nil {
+		return err
+	}
+	if modified.IsZero() {
+		return nil
+	}
+	return os.Chtimes(op.Target, modified, modified)
+}
+
+// uploadTreeFile uploads a local file with AddChunked
+func uploadTreeFile(web *Web, op *TreeSyncOperation, options *TreeSyncOptions) error {
+	f, err := os.Open(op.Source)
+	if err != nil {
+		return err
+	}
+	defer shut(f)
+	folderURL := path.Dir(op.Target)
+	_, err = web.GetFolderByPath(escapeResourcePath(folderURL)).Files().AddChunked(path.Base(op.Target), f, &AddChunkedOptions{
+		Overwrite: true,
+		ChunkSize: options.ChunkSize,
+	})
+	return err
+}
+
+// runTreeOperations runs file operations of the action with bounded concurrency
+func runTreeOperations(ops []*TreeSyncOperation, action string, concurrency int, run func(op *TreeSyncOperation) error) {
+	var wg sync.WaitGroup
+	queue := make(chan *TreeSyncOperation)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for op := range queue {
+				op.Error = run(op)
+			}
+		}()
+	}
+	for _, op := range ops {
+		if op.Action == action {
+			queue <- op
+		}
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// complete sorts operations and counts failures
+func (report *TreeSyncReport) complete() *TreeSyncReport {
+	sort.SliceStable(report.Operations, func(i, j int) bool {
+		return report.Operations[i].Path < report.Operations[j].Path
+	})
+	report.Failed = 0
+	for _, op := range report.Operations {
+		if op.Error != nil {
+			report.Failed++
+		}
+	}
+	return report
+}
+
+// treeSyncAction decides if a file should be transferred based on the mode, `target` is nil when missing
+func treeSyncAction(action string, source *treeFile, target *treeFile, mode string) (string, string) {
+	if target == nil {
+		return action, "missing"
+	}
+	switch mode {
+	case TreeSyncModes.Skip:
+		return "skip", "exists"
+	case TreeSyncModes.Newer:
+		if source.size != target.size {
+			return action, "length"
+		}
+		// comparing with seconds precision as file systems and SharePoint store times differently
+		if source.modified.Truncate(time.Second).After(target.modified.Truncate(time.Second)) {
+			return action, "newer"
+		}
+		return "skip", "not newer"
+	}
+	return action, "overwrite"
+}
+
+// treeFileIncluded checks file path against include and exclude filters
+func treeFileIncluded(rel string, options *TreeSyncOptions) bool {
+	if matchTreeGlobs(options.Exclude, rel) {
+		return false
+	}
+	return len(options.Include) == 0 || matchTreeGlobs(options.Include, rel)
+}
+
+// matchTreeGlobs checks if a relative path matches any of the glob patterns
+func matchTreeGlobs(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.Trim(filepath.ToSlash(pattern), "/")
+		if strings.HasSuffix(pattern, "/**") {
+			prefix := strings.TrimSuffix(pattern, "/**")
+			if rel == prefix || strings.HasPrefix(rel, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		target := rel
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(rel)
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// getTreeSyncOptions applies default tree sync options
+func getTreeSyncOptions(options *TreeSyncOptions) *TreeSyncOptions {
+	opts := TreeSyncOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.Mode == "" {
+		opts.Mode = TreeSyncModes.Overwrite
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 5
+	}
+	return &opts
+}
+
+// MarshalJSON marshals operation with the error as a string
+func (op *TreeSyncOperation) MarshalJSON() ([]byte, error) {
+	type operation TreeSyncOperation
+	errMsg := ""
+	if op.Error != nil {
+		errMsg = op.Error.Error()
+	}
+	return json.Marshal(&struct {
+		*operation
+		Error string `json:"Error,omitempty"`
+	}{operation: (*operation)(op), Error: errMsg})
+}
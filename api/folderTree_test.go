@@ -0,0 +1,150 @@
+package api
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestFolderTree(t *testing.T) {
+
+	t.Run("Globs", func(t *testing.T) {
+		cases := []struct {
+			patterns []string
+			path     string
+			match    bool
+		}{
+			{[]string{"*.tmp"}, "a/b/file.tmp", true},
+			{[]string{"a/*.txt"}, "a/file.txt", true},
+			{[]string{"a/*.txt"}, "a/b/file.txt", false},
+			{[]string{"a/**"}, "a/b/file.txt", true},
+			{[]string{"a/**"}, "ab/file.txt", false},
+			{[]string{"*.pdf", "*.docx"}, "doc.docx", true},
+		}
+		for _, c := range cases {
+			if matchTreeGlobs(c.patterns, c.path) != c.match {
+				t.Errorf("%v should match %s: %t", c.patterns, c.path, c.match)
+			}
+		}
+	})
+
+	t.Run("Modes", func(t *testing.T) {
+		now := time.Now()
+		source := &treeFile{size: 10, modified: now}
+		if action, _ := treeSyncAction("upload", source, nil, TreeSyncModes.Skip); action != "upload" {
+			t.Error("missing file should be transferred")
+		}
+		if action, _ := treeSyncAction("upload", source, &treeFile{size: 10}, TreeSyncModes.Skip); action != "skip" {
+			t.Error("existing file should be skipped")
+		}
+		if action, _ := treeSyncAction("upload", source, &treeFile{size: 10, modified: now.Add(-time.Hour)}, TreeSyncModes.Newer); action != "upload" {
+			t.Error("newer file should be transferred")
+		}
+		if action, _ := treeSyncAction("upload", source, &treeFile{size: 10, modified: now.Add(time.Hour)}, TreeSyncModes.Newer); action != "skip" {
+			t.Error("older file should be skipped")
+		}
+		if action, _ := treeSyncAction("upload", source, &treeFile{size: 5, modified: now.Add(time.Hour)}, TreeSyncModes.Newer); action != "upload" {
+			t.Error("file with different length should be transferred")
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	newFolderName := uuid.New().String()
+	rootFolderURI := getRelativeURL(spClient.AuthCnfg.GetSiteURL()) + "/Shared%20Documents"
+	newFolderURI := rootFolderURI + "/" + newFolderName
+	if _, err := web.GetFolder(rootFolderURI).Folders().Add(newFolderName); err != nil {
+		t.Error(err)
+	}
+
+	localDir := t.TempDir()
+	files := map[string][]byte{
+		"a.txt":         []byte("a"),
+		"sub/b.txt":     []byte("b"),
+		"sub/c.tmp":     []byte("c"),
+		"sub/deep/d.md": []byte("d"),
+	}
+	for name, content := range files {
+		p := filepath.Join(localDir, filepath.FromSlash(name))
+		_ = os.MkdirAll(filepath.Dir(p), 0755)
+		if err := ioutil.WriteFile(p, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	options := &TreeSyncOptions{Exclude: []string{"*.tmp"}, Mode: TreeSyncModes.Newer}
+
+	t.Run("UploadTree", func(t *testing.T) {
+		if envCode == "2013" {
+			t.Skip("is not supported with SP 2013")
+		}
+		plan, err := web.GetFolder(newFolderURI).UploadTree(localDir, &TreeSyncOptions{Exclude: options.Exclude, DryRun: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		uploads := 0
+		for _, op := range plan.Operations {
+			if op.Action == "upload" {
+				uploads++
+			}
+		}
+		if uploads != 3 {
+			t.Errorf("incorrect upload plan: %d uploads", uploads)
+		}
+		report, err := web.GetFolder(newFolderURI).UploadTree(localDir, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Failed > 0 {
+			for _, op := range report.Operations {
+				if op.Error != nil {
+					t.Errorf("%s %s: %s", op.Action, op.Path, op.Error)
+				}
+			}
+		}
+	})
+
+	t.Run("DownloadTree", func(t *testing.T) {
+		if envCode == "2013" {
+			t.Skip("is not supported with SP 2013")
+		}
+		targetDir := t.TempDir()
+		report, err := web.GetFolder(newFolderURI).DownloadTree(targetDir, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Failed > 0 {
+			t.Errorf("failed operations: %d", report.Failed)
+		}
+		for name, content := range files {
+			data, err := ioutil.ReadFile(filepath.Join(targetDir, filepath.FromSlash(name)))
+			if name == "sub/c.tmp" {
+				if err == nil {
+					t.Error("excluded file should not be transferred")
+				}
+				continue
+			}
+			if !bytes.Equal(data, content) {
+				t.Errorf("incorrect %s content", name)
+			}
+		}
+		plan, err := web.GetFolder(newFolderURI).DownloadTree(targetDir, &TreeSyncOptions{Mode: TreeSyncModes.Newer, DryRun: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, op := range plan.Operations {
+			if op.Action != "skip" {
+				t.Errorf("unchanged file should be skipped: %s", op.Path)
+			}
+		}
+	})
+
+	if err := web.GetFolder(newFolderURI).Delete(); err != nil {
+		t.Error(err)
+	}
+}
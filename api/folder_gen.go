@@ -1,4 +1,4 @@
-// Code generated by `ggen -ent Folder -conf -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
+// Code generated by `ggen -ent Folder -conf -etag -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
 
 package api
 
@@ -38,3 +38,18 @@ func (folderResp *FolderResp) Data() *FolderInfo {
 func (folderResp *FolderResp) Normalized() []byte {
 	return NormalizeODataItem(*folderResp)
 }
+
+/* Optimistic concurrency helpers */
+
+// IfMatch gets a copy of the entity with the expected ETag for Update and Delete requests,
+// the requests fail with *PreconditionFailedError when the entity was modified since the ETag was received
+func (folder *Folder) IfMatch(etag string) *Folder {
+	scoped := *folder
+	scoped.config = withIfMatch(folder.config, etag)
+	return &scoped
+}
+
+// ETag gets entity ETag from the response metadata
+func (folderResp *FolderResp) ETag() string {
+	return getODataETag(*folderResp)
+}
@@ -49,6 +49,14 @@ func (folders *Folders) Add(folderName string) (FolderResp, error) {
 	return client.Post(endpoint, nil, folders.config)
 }
 
+// AddUsingPath created a folder with specified name in this folder using ResourcePath API
+// Supported only in modern SharePoint, differs from Add with its capability of dealing with special chars in name (`'`, `#`, `%`)
+func (folders *Folders) AddUsingPath(folderName string) (FolderResp, error) {
+	client := NewHTTPClient(folders.client)
+	endpoint := fmt.Sprintf("%s/AddUsingPath(decodedUrl='%s')", folders.endpoint, escapeResourcePath(folderName))
+	return client.Post(endpoint, nil, folders.config)
+}
+
 // GetByName gets a folder by its name in this folder
 func (folders *Folders) GetByName(folderName string) *Folder {
 	return NewFolder(
@@ -55,3 +55,36 @@ func (foldersResp *FoldersResp) Normalized() []byte {
 	normalized, _ := NormalizeODataCollection(*foldersResp)
 	return normalized
 }
+
+/* Pagination helpers */
+
+// FoldersPager - Folders collection pages iterator
+type FoldersPager struct {
+	*Pager
+}
+
+// Pager gets Folders collection pages iterator, the iteration follows OData next page links
+func (folders *Folders) Pager() *FoldersPager {
+	return &FoldersPager{NewPager(folders.client, folders.ToURL(), folders.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *FoldersPager) Prefetch(enabled bool) *FoldersPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *FoldersPager) Page() FoldersResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (foldersResp *FoldersResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*foldersResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (foldersResp *FoldersResp) HasNextPage() bool {
+	return foldersResp.NextPageURL() != ""
+}
@@ -56,6 +56,22 @@ func TestFolders(t *testing.T) {
 		}
 	})
 
+	t.Run("AddUsingPath", func(t *testing.T) {
+		if envCode != "spo" {
+			t.Skip("is not supported with legacy SP")
+		}
+
+		name := "O'Brien #1 100%"
+		folders := web.GetFolderByPath(rootFolderURI + "/" + newFolderName).Folders()
+		data, err := folders.AddUsingPath(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.Data().Name != name {
+			t.Errorf("incorrect folder name, expected `%s`, got `%s`", name, data.Data().Name)
+		}
+	})
+
 	t.Run("GetFolderByID", func(t *testing.T) {
 		if envCode != "spo" {
 			t.Skip("is not supported with legacy SP")
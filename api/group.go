@@ -10,7 +10,7 @@ import (
 	"github.com/pnocera/gosip/csom"
 )
 
-//go:generate ggen -ent Group -conf -mods Select,Expand -helpers Data,Normalized
+//go:generate ggen -ent Group -conf -etag -mods Select,Expand -helpers Data,Normalized
 
 // Group represents SharePoint Site Groups API queryable object struct
 // Always use NewGroup constructor instead of &Group{}
@@ -37,6 +37,15 @@ type GroupInfo struct {
 	Title                          string `json:"Title"`
 }
 
+// GroupSettings - site group membership settings
+type GroupSettings struct {
+	AllowMembersEditMembership     bool   // group members can edit the membership
+	AllowRequestToJoinLeave        bool   // users can request to join or leave the group
+	AutoAcceptRequestToJoinLeave   bool   // join or leave requests are accepted automatically
+	OnlyAllowMembersViewMembership bool   // only group members can view the membership
+	RequestToJoinLeaveEmailSetting string // email address the membership requests are sent to
+}
+
 // GroupResp - group response type with helper processor methods
 type GroupResp []byte
 
@@ -69,6 +78,38 @@ func (group *Group) Update(body []byte) (GroupResp, error) {
 	return client.Update(group.endpoint, bytes.NewBuffer(body), group.config)
 }
 
+// Settings gets the group membership settings
+func (group *Group) Settings() (*GroupSettings, error) {
+	data, err := NewGroup(group.client, group.endpoint, group.config).
+		Select("AllowMembersEditMembership,AllowRequestToJoinLeave,AutoAcceptRequestToJoinLeave,OnlyAllowMembersViewMembership,RequestToJoinLeaveEmailSetting").
+		Get()
+	if err != nil {
+		return nil, err
+	}
+	settings := &GroupSettings{}
+	if err := json.Unmarshal(data.Normalized(), &settings); err != nil {
+		return nil, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	return settings, nil
+}
+
+// UpdateSettings updates the group membership settings
+func (group *Group) UpdateSettings(settings *GroupSettings) (GroupResp, error) {
+	body, _ := json.Marshal(settings.toMetadata())
+	return group.Update(body)
+}
+
+// toMetadata gets SP.Group properties payload of the settings
+func (settings *GroupSettings) toMetadata() map[string]interface{} {
+	return map[string]interface{}{
+		"AllowMembersEditMembership":     settings.AllowMembersEditMembership,
+		"AllowRequestToJoinLeave":        settings.AllowRequestToJoinLeave,
+		"AutoAcceptRequestToJoinLeave":   settings.AutoAcceptRequestToJoinLeave,
+		"OnlyAllowMembersViewMembership": settings.OnlyAllowMembersViewMembership,
+		"RequestToJoinLeaveEmailSetting": settings.RequestToJoinLeaveEmailSetting,
+	}
+}
+
 // Users gets Users API queryable collection
 func (group *Group) Users() *Users {
 	return NewUsers(
@@ -110,7 +151,7 @@ func (group *Group) SetUserAsOwner(userID int) error {
 	return err
 }
 
-// SetOwner sets a user or group as this group owner
+// SetOwner sets a user or group as this group owner, the principal type is detected by the ID
 func (group *Group) SetOwner(ownerID int) error {
 	site := NewSite(
 		group.client,
@@ -118,38 +159,48 @@ func (group *Group) SetOwner(ownerID int) error {
 		group.config,
 	)
 
-	cg, err := group.Select("Id").Get()
-	if err != nil {
-		return err
-	}
-
 	var principal struct {
 		ContentType struct {
 			Name string
 		}
 	}
 
-	pType := "group"
+	isGroup := true
 	pData, err := site.RootWeb().UserInfoList().Items().Expand("ContentType").Filter(fmt.Sprintf("Id eq %d", ownerID)).Get()
 	if err != nil {
-		return nil
+		return err
 	}
 	if len(pData.Data()) > 0 {
 		if err := json.Unmarshal(pData.Data()[0].Normalized(), &principal); err != nil {
 			return err
 		}
 		if principal.ContentType.Name == "Person" {
-			pType = "user"
+			isGroup = false
 		}
 	}
 
+	return group.setOwner(ownerID, isGroup)
+}
+
+// SetOwnerGroup sets another site group as this group owner
+func (group *Group) SetOwnerGroup(ownerGroupID int) error {
+	return group.setOwner(ownerGroupID, true)
+}
+
+// setOwner sets a user or group as this group owner (CSOM helper)
+func (group *Group) setOwner(ownerID int, isGroup bool) error {
+	cg, err := NewGroup(group.client, group.endpoint, group.config).Select("Id").Get()
+	if err != nil {
+		return err
+	}
+
 	b := csom.NewBuilder()
 	wo, _ := b.AddObject(csom.NewObjectProperty("Web"), nil)
 	sg, _ := b.AddObject(csom.NewObjectProperty("SiteGroups"), wo)
 	gr, _ := b.AddObject(csom.NewObjectMethod("GetById", []string{fmt.Sprintf(`<Parameter Type="Number">%d</Parameter>`, cg.Data().ID)}), sg)
 	owner := csom.NewObjectMethod("GetById", []string{fmt.Sprintf(`<Parameter Type="Number">%d</Parameter>`, ownerID)})
 
-	if pType == "group" {
+	if isGroup {
 		owner, _ = b.AddObject(owner, sg)
 	} else {
 		su, _ := b.AddObject(csom.NewObjectProperty("SiteUsers"), wo)
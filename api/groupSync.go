@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// GroupSyncOptions provides optional settings for Group.SyncMembers method
+type GroupSyncOptions struct {
+	DryRun            bool // only plan the changes without applying
+	Concurrency       int  // number of parallel requests, 5 by default
+	ProtectOwner      bool // never remove the group owner
+	ProtectSiteAdmins bool // never remove site collection administrators
+}
+
+// GroupSyncResult describes planned or applied group membership changes
+type GroupSyncResult struct {
+	DryRun    bool              // changes were only planned
+	Add       []*UserInfo       // users added (or to be added) to the group
+	Remove    []*UserInfo       // users removed (or to be removed) from the group
+	Protected []*UserInfo       // extra members kept due to protection options
+	Errors    []*GroupSyncError // per principal failures, the sync continues for the rest
+}
+
+// GroupSyncError describes a failure for a principal during the membership sync
+type GroupSyncError struct {
+	LoginName string // desired login or member login name
+	Operation string // "resolve", "add" or "remove"
+	Err       error  // underlying error
+}
+
+// Error implements error interface
+func (e *GroupSyncError) Error() string {
+	return fmt.Sprintf("unable to %s %s: %s", e.Operation, e.LoginName, e.Err)
+}
+
+// Unwrap gets the underlying error
+func (e *GroupSyncError) Unwrap() error {
+	return e.Err
+}
+
+// SyncMembers makes the group membership match the desired logins (login names or emails):
+// missing users are ensured in the site and added, the members not in the list are removed.
+// Unresolvable logins are reported in the result Errors, their matching members are never removed.
+// The returned error is only for the failures preventing the sync, e.g. unavailable group.
+func (group *Group) SyncMembers(desiredLogins []string, options *GroupSyncOptions) (*GroupSyncResult, error) {
+	if options == nil {
+		options = &GroupSyncOptions{}
+	}
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = 5
+	}
+	result := &GroupSyncResult{DryRun: options.DryRun}
+
+	members, err := group.members()
+	if err != nil {
+		return nil, err
+	}
+	ownerID := 0
+	if options.ProtectOwner {
+		if ownerID, err = group.ownerID(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Resolve desired logins
+	web := NewWeb(group.client, getPriorEndpoint(group.endpoint, "/_api")+"/_api/Web", group.config)
+	var mu sync.Mutex
+	resolved := make([]*UserInfo, len(desiredLogins))
+	unresolved := map[string]bool{}
+	runBounded(concurrency, len(desiredLogins), func(i int) {
+		login := desiredLogins[i]
+		user, err := web.EnsureUser(login)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			unresolved[strings.ToLower(login)] = true
+			result.Errors = append(result.Errors, &GroupSyncError{LoginName: login, Operation: "resolve", Err: err})
+			return
+		}
+		resolved[i] = user
+	})
+	result.Add, result.Remove, result.Protected = planGroupSync(members, resolved, unresolved, ownerID, options)
+
+	if options.DryRun {
+		return result, nil
+	}
+
+	// Apply, additions go first
+	var added, removed []*UserInfo
+	runBounded(concurrency, len(result.Add), func(i int) {
+		user := result.Add[i]
+		err := group.AddUser(user.LoginName)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			result.Errors = append(result.Errors, &GroupSyncError{LoginName: user.LoginName, Operation: "add", Err: err})
+			return
+		}
+		added = append(added, user)
+	})
+	runBounded(concurrency, len(result.Remove), func(i int) {
+		user := result.Remove[i]
+		err := group.RemoveUserByID(user.ID)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			result.Errors = append(result.Errors, &GroupSyncError{LoginName: user.LoginName, Operation: "remove", Err: err})
+			return
+		}
+		removed = append(removed, user)
+	})
+	result.Add = added
+	result.Remove = removed
+	return result, nil
+}
+
+// members gets all group users
+func (group *Group) members() ([]*UserInfo, error) {
+	var members []*UserInfo
+	pager := group.Users().Select("Id,LoginName,Email,Title,IsSiteAdmin,PrincipalType").Top(5000).Pager()
+	for pager.Next() {
+		page := pager.Page()
+		for _, u := range page.Data() {
+			members = append(members, u.Data())
+		}
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// ownerID gets the group owner principal ID
+func (group *Group) ownerID() (int, error) {
+	data, err := NewGroup(group.client, group.endpoint, group.config).Select("Owner/Id").Expand("Owner").Get()
+	if err != nil {
+		return 0, err
+	}
+	res := &struct {
+		Owner *struct {
+			ID int `json:"Id"`
+		} `json:"Owner"`
+	}{}
+	if err := json.Unmarshal(data.Normalized(), &res); err != nil {
+		return 0, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	if res.Owner == nil {
+		return 0, nil
+	}
+	return res.Owner.ID, nil
+}
+
+// planGroupSync gets users to add and remove, and protected members which would be removed otherwise,
+// `resolved` are desired users with nils for `unresolved` lower cased logins
+func planGroupSync(members []*UserInfo, resolved []*UserInfo, unresolved map[string]bool, ownerID int, options *GroupSyncOptions) (add, remove, protected []*UserInfo) {
+	desired := map[int]bool{}
+	for _, user := range resolved {
+		if user != nil {
+			desired[user.ID] = true
+		}
+	}
+	current := map[int]bool{}
+	for _, m := range members {
+		current[m.ID] = true
+		if desired[m.ID] {
+			continue
+		}
+		if unresolved[strings.ToLower(m.LoginName)] || (m.Email != "" && unresolved[strings.ToLower(m.Email)]) {
+			continue
+		}
+		if (options.ProtectOwner && m.ID == ownerID) || (options.ProtectSiteAdmins && m.IsSiteAdmin) {
+			protected = append(protected, m)
+			continue
+		}
+		remove = append(remove, m)
+	}
+	for _, user := range resolved {
+		if user != nil && !current[user.ID] {
+			current[user.ID] = true
+			add = append(add, user)
+		}
+	}
+	return add, remove, protected
+}
+
+// runBounded runs the task for indexes [0, n) with limited concurrency and waits for completion
+func runBounded(concurrency int, n int, task func(i int)) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			task(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGroupSync(t *testing.T) {
+
+	t.Run("PlanGroupSync", func(t *testing.T) {
+		members := []*UserInfo{
+			{ID: 1, LoginName: "i:0#.f|membership|owner@contoso.com"},
+			{ID: 2, LoginName: "i:0#.f|membership|admin@contoso.com", IsSiteAdmin: true},
+			{ID: 3, LoginName: "i:0#.f|membership|keep@contoso.com"},
+			{ID: 4, LoginName: "i:0#.f|membership|gone@contoso.com"},
+			{ID: 5, LoginName: "i:0#.f|membership|broken@contoso.com", Email: "broken@contoso.com"},
+		}
+		resolved := []*UserInfo{
+			{ID: 3, LoginName: "i:0#.f|membership|keep@contoso.com"},
+			nil,
+			{ID: 6, LoginName: "i:0#.f|membership|new@contoso.com"},
+			{ID: 6, LoginName: "i:0#.f|membership|new@contoso.com"},
+		}
+		unresolved := map[string]bool{"broken@contoso.com": true}
+		options := &GroupSyncOptions{ProtectOwner: true, ProtectSiteAdmins: true}
+
+		add, remove, protected := planGroupSync(members, resolved, unresolved, 1, options)
+		if len(add) != 1 || add[0].ID != 6 {
+			t.Errorf("unexpected users to add: %+v", add)
+		}
+		if len(remove) != 1 || remove[0].ID != 4 {
+			t.Errorf("unexpected users to remove: %+v", remove)
+		}
+		if len(protected) != 2 {
+			t.Errorf("unexpected protected users: %+v", protected)
+		}
+
+		_, remove, _ = planGroupSync(members, resolved, unresolved, 1, &GroupSyncOptions{})
+		if len(remove) != 3 {
+			t.Errorf("unexpected users to remove without protection: %+v", remove)
+		}
+	})
+
+	t.Run("RunBounded", func(t *testing.T) {
+		var running, peak, total int32
+		runBounded(3, 20, func(i int) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			atomic.AddInt32(&total, 1)
+			atomic.AddInt32(&running, -1)
+		})
+		if total != 20 {
+			t.Errorf("expected 20 tasks, got %d", total)
+		}
+		if peak > 3 {
+			t.Errorf("concurrency limit exceeded: %d", peak)
+		}
+	})
+
+	t.Run("GroupSyncError", func(t *testing.T) {
+		inner := errors.New("not found")
+		err := error(&GroupSyncError{LoginName: "user", Operation: "resolve", Err: inner})
+		if !errors.Is(err, inner) {
+			t.Error("should unwrap the underlying error")
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	newGroupName := uuid.New().String()
+	data, err := web.SiteGroups().AddWithMetadata(newGroupName, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	group := web.SiteGroups().GetByID(data.Data().ID)
+	user, err := web.CurrentUser().Select("LoginName").Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	desired := []string{user.Data().LoginName, "i:0#.f|membership|" + uuid.New().String() + "@nowhere.local"}
+
+	t.Run("DryRun", func(t *testing.T) {
+		before, err := group.members()
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := group.SyncMembers(desired, &GroupSyncOptions{DryRun: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Errors) != 1 || res.Errors[0].Operation != "resolve" {
+			t.Errorf("unresolvable login should have been reported, got %+v", res.Errors)
+		}
+		after, err := group.members()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(before) != len(after) {
+			t.Error("dry run should not have changed membership")
+		}
+	})
+
+	t.Run("SyncMembers", func(t *testing.T) {
+		if _, err := group.SyncMembers(desired, nil); err != nil {
+			t.Fatal(err)
+		}
+		res, err := group.SyncMembers(desired, &GroupSyncOptions{DryRun: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Add) != 0 || len(res.Remove) != 0 {
+			t.Errorf("membership should have been in sync, got %d adds and %d removes", len(res.Add), len(res.Remove))
+		}
+	})
+
+	if err := web.SiteGroups().RemoveByID(data.Data().ID); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,4 +1,4 @@
-// Code generated by `ggen -ent Group -conf -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
+// Code generated by `ggen -ent Group -conf -etag -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
 
 package api
 
@@ -38,3 +38,18 @@ func (groupResp *GroupResp) Data() *GroupInfo {
 func (groupResp *GroupResp) Normalized() []byte {
 	return NormalizeODataItem(*groupResp)
 }
+
+/* Optimistic concurrency helpers */
+
+// IfMatch gets a copy of the entity with the expected ETag for Update and Delete requests,
+// the requests fail with *PreconditionFailedError when the entity was modified since the ETag was received
+func (group *Group) IfMatch(etag string) *Group {
+	scoped := *group
+	scoped.config = withIfMatch(group.config, etag)
+	return &scoped
+}
+
+// ETag gets entity ETag from the response metadata
+func (groupResp *GroupResp) ETag() string {
+	return getODataETag(*groupResp)
+}
@@ -28,7 +28,7 @@ func TestGroup(t *testing.T) {
 	}
 
 	t.Run("Add", func(t *testing.T) {
-		data, err := web.SiteGroups().Add(newGroupName, nil)
+		data, err := web.SiteGroups().AddWithMetadata(newGroupName, nil)
 		if err != nil {
 			t.Error(err)
 		}
@@ -128,6 +128,46 @@ func TestGroup(t *testing.T) {
 		}
 	})
 
+	t.Run("SetOwnerGroup", func(t *testing.T) {
+		og, err := web.AssociatedGroups().Owners().Select("Id").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		g := web.SiteGroups().GetByID(group.ID)
+		if err := g.SetOwnerGroup(og.Data().ID); err != nil {
+			t.Error(err)
+		}
+		o, err := g.Select("Owner/Id").Expand("Owner").Get()
+		if err != nil {
+			t.Error(err)
+		}
+		var owner *groupOwner
+		if err := json.Unmarshal(o.Normalized(), &owner); err != nil {
+			t.Error(err)
+		}
+		if owner.Owner.ID != og.Data().ID {
+			t.Error("can't set a group as group owner")
+		}
+	})
+
+	t.Run("UpdateSettings", func(t *testing.T) {
+		g := web.SiteGroups().GetByID(group.ID)
+		settings := &GroupSettings{
+			AllowMembersEditMembership:     true,
+			OnlyAllowMembersViewMembership: false,
+		}
+		if _, err := g.UpdateSettings(settings); err != nil {
+			t.Error(err)
+		}
+		s, err := g.Settings()
+		if err != nil {
+			t.Error(err)
+		}
+		if !s.AllowMembersEditMembership || s.OnlyAllowMembersViewMembership {
+			t.Errorf("settings were not applied: %+v", s)
+		}
+	})
+
 	t.Run("SetUserAsOwner", func(t *testing.T) {
 		if envCode == "2013" {
 			t.Skip("is not supported with SP 2013")
@@ -19,6 +19,14 @@ type Groups struct {
 	modifiers *ODataMods
 }
 
+// GroupCreationInfo new site group metadata
+type GroupCreationInfo struct {
+	Title       string         // Group name
+	Description string         // Description text
+	OwnerID     int            // Owner user or group ID, optional, the current user is the owner by default
+	Settings    *GroupSettings // Membership settings, optional, SharePoint defaults are used when not provided
+}
+
 // GroupsResp - groups response type with helper processor methods
 type GroupsResp []byte
 
@@ -43,9 +51,9 @@ func (groups *Groups) Get() (GroupsResp, error) {
 	return client.Get(groups.ToURL(), groups.config)
 }
 
-// Add creates new group with a specified name. Additional metadata can optionally be provided as string map object.
+// AddWithMetadata creates new group with a specified name. Additional metadata can optionally be provided as string map object.
 // `metadata` should correspond to SP.Group type.
-func (groups *Groups) Add(title string, metadata map[string]interface{}) (GroupResp, error) {
+func (groups *Groups) AddWithMetadata(title string, metadata map[string]interface{}) (GroupResp, error) {
 	if metadata == nil {
 		metadata = make(map[string]interface{})
 	}
@@ -58,6 +66,31 @@ func (groups *Groups) Add(title string, metadata map[string]interface{}) (GroupR
 	return client.Post(groups.endpoint, bytes.NewBuffer(body), groups.config)
 }
 
+// Add creates new group with the typed metadata, settings and owner
+func (groups *Groups) Add(info GroupCreationInfo) (GroupResp, error) {
+	metadata := map[string]interface{}{}
+	if info.Description != "" {
+		metadata["Description"] = info.Description
+	}
+	if info.Settings != nil {
+		for k, v := range info.Settings.toMetadata() {
+			metadata[k] = v
+		}
+	}
+	data, err := groups.AddWithMetadata(info.Title, metadata)
+	if err != nil {
+		return nil, err
+	}
+	if info.OwnerID == 0 {
+		return data, nil
+	}
+	group := groups.GetByID(data.Data().ID)
+	if err := group.SetOwner(info.OwnerID); err != nil {
+		return data, err
+	}
+	return group.Get()
+}
+
 // GetByID gets a group object by its ID
 func (groups *Groups) GetByID(groupID int) *Group {
 	return NewGroup(
@@ -71,7 +104,7 @@ func (groups *Groups) GetByID(groupID int) *Group {
 func (groups *Groups) GetByName(groupName string) *Group {
 	return NewGroup(
 		groups.client,
-		fmt.Sprintf("%s/GetByName('%s')", groups.endpoint, groupName),
+		fmt.Sprintf("%s/GetByName('%s')", groups.endpoint, escapeODataString(groupName)),
 		groups.config,
 	)
 }
@@ -89,7 +122,7 @@ func (groups *Groups) RemoveByLoginName(loginName string) error {
 	endpoint := fmt.Sprintf(
 		"%s/RemoveByLoginName('%s')",
 		groups.endpoint,
-		loginName, // url.QueryEscape(loginName),
+		escapeODataString(loginName),
 	)
 	client := NewHTTPClient(groups.client)
 	_, err := client.Post(endpoint, nil, groups.config)
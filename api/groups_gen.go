@@ -55,3 +55,36 @@ func (groupsResp *GroupsResp) Normalized() []byte {
 	normalized, _ := NormalizeODataCollection(*groupsResp)
 	return normalized
 }
+
+/* Pagination helpers */
+
+// GroupsPager - Groups collection pages iterator
+type GroupsPager struct {
+	*Pager
+}
+
+// Pager gets Groups collection pages iterator, the iteration follows OData next page links
+func (groups *Groups) Pager() *GroupsPager {
+	return &GroupsPager{NewPager(groups.client, groups.ToURL(), groups.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *GroupsPager) Prefetch(enabled bool) *GroupsPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *GroupsPager) Page() GroupsResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (groupsResp *GroupsResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*groupsResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (groupsResp *GroupsResp) HasNextPage() bool {
+	return groupsResp.NextPageURL() != ""
+}
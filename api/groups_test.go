@@ -102,7 +102,7 @@ func TestGroups(t *testing.T) {
 	})
 
 	t.Run("Add", func(t *testing.T) {
-		if _, err := groups.Conf(headers.verbose).Add(newGroupName, nil); err != nil {
+		if _, err := groups.Conf(headers.verbose).Add(GroupCreationInfo{Title: newGroupName}); err != nil {
 			t.Error(err)
 		}
 
@@ -119,8 +119,31 @@ func TestGroups(t *testing.T) {
 		}
 	})
 
+	t.Run("AddWithInfo", func(t *testing.T) {
+		og, err := sp.Web().AssociatedGroups().Owners().Select("Id").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		info := GroupCreationInfo{
+			Title:       uuid.New().String(),
+			Description: "Created by tests",
+			OwnerID:     og.Data().ID,
+			Settings:    &GroupSettings{AutoAcceptRequestToJoinLeave: true, AllowRequestToJoinLeave: true},
+		}
+		data, err := groups.Add(info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !data.Data().AutoAcceptRequestToJoinLeave || data.Data().OwnerTitle == "" {
+			t.Errorf("group settings were not applied: %+v", data.Data())
+		}
+		if err := groups.RemoveByID(data.Data().ID); err != nil {
+			t.Error(err)
+		}
+	})
+
 	t.Run("RemoveByLoginName", func(t *testing.T) {
-		if _, err := groups.Conf(headers.verbose).Add(newGroupNameRemove, nil); err != nil {
+		if _, err := groups.Conf(headers.verbose).Add(GroupCreationInfo{Title: newGroupNameRemove}); err != nil {
 			t.Error(err)
 		}
 		if err := groups.RemoveByLoginName(newGroupNameRemove); err != nil {
@@ -53,6 +53,23 @@ var HeadersPresets = struct {
 	},
 }
 
+// PreconditionFailedError is returned by Update and Delete requests when the entity was modified
+// since the expected ETag provided with IfMatch was received
+type PreconditionFailedError struct {
+	ETag string // expected ETag
+	Err  error  // response error with details
+}
+
+// Error implements error interface
+func (e *PreconditionFailedError) Error() string {
+	return fmt.Sprintf("precondition failed, the entity was modified since ETag %s was received: %s", e.ETag, e.Err)
+}
+
+// Unwrap gets the response error
+func (e *PreconditionFailedError) Unwrap() error {
+	return e.Err
+}
+
 // NewHTTPClient creates an instance of httpClient
 func NewHTTPClient(spClient *gosip.SPClient) *HTTPClient {
 	return &HTTPClient{sp: spClient}
@@ -79,6 +96,7 @@ func (client *HTTPClient) Get(endpoint string, conf *RequestConfig) ([]byte, err
 			req.Header.Set(key, value)
 		}
 	}
+	dropScopedIfMatch(req)
 
 	resp, err := client.sp.Execute(req)
 	if err != nil {
@@ -112,10 +130,11 @@ func (client *HTTPClient) Post(endpoint string, body io.Reader, conf *RequestCon
 			req.Header.Set(key, value)
 		}
 	}
+	dropScopedIfMatch(req)
 
 	resp, err := client.sp.Execute(req)
 	if err != nil {
-		return nil, fmt.Errorf("unable to request api: %w", err)
+		return nil, requestError(req, resp, err)
 	}
 	defer shut(resp.Body)
 
@@ -149,7 +168,7 @@ func (client *HTTPClient) Delete(endpoint string, conf *RequestConfig) ([]byte,
 
 	resp, err := client.sp.Execute(req)
 	if err != nil {
-		return nil, fmt.Errorf("unable to request api: %w", err)
+		return nil, requestError(req, resp, err)
 	}
 	defer shut(resp.Body)
 
@@ -184,13 +203,21 @@ func (client *HTTPClient) Update(endpoint string, body io.Reader, conf *RequestC
 
 	resp, err := client.sp.Execute(req)
 	if err != nil {
-		return nil, fmt.Errorf("unable to request api: %w", err)
+		return nil, requestError(req, resp, err)
 	}
 	defer shut(resp.Body)
 
 	return ioutil.ReadAll(resp.Body)
 }
 
+// dropScopedIfMatch removes If-Match header inherited from a config scoped with IfMatch,
+// the expected ETag applies to Update and Delete requests only, tunneled methods (X-HTTP-Method) keep it
+func dropScopedIfMatch(req *http.Request) {
+	if req.Header.Get("X-Http-Method") == "" {
+		req.Header.Del("If-Match")
+	}
+}
+
 // ProcessQuery - CSOM requests helper
 func (client *HTTPClient) ProcessQuery(endpoint string, body io.Reader, conf *RequestConfig) ([]byte, error) {
 	if strings.Index(strings.ToLower(endpoint), strings.ToLower("/_vti_bin/client.svc/ProcessQuery")) == -1 {
@@ -272,3 +299,11 @@ func (client *HTTPClient) ProcessQuery(endpoint string, body io.Reader, conf *Re
 
 	return data, nil
 }
+
+// requestError wraps request error, HTTP 412 responses are converted to *PreconditionFailedError
+func requestError(req *http.Request, resp *http.Response, err error) error {
+	if resp != nil && resp.StatusCode == http.StatusPreconditionFailed {
+		return &PreconditionFailedError{ETag: req.Header.Get("If-Match"), Err: err}
+	}
+	return fmt.Errorf("unable to request api: %w", err)
+}
@@ -2,11 +2,28 @@ package api
 
 import (
 	"bytes"
+	"net/http"
 	"strings"
 	"testing"
 )
 
 func TestHttp(t *testing.T) {
+
+	t.Run("dropScopedIfMatch", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", "https://contoso.sharepoint.com/_api/Web/Lists", nil)
+		req.Header.Set("If-Match", `"1"`)
+		dropScopedIfMatch(req)
+		if req.Header.Get("If-Match") != "" {
+			t.Error("If-Match should have been removed")
+		}
+		req.Header.Set("If-Match", `"1"`)
+		req.Header.Set("X-HTTP-Method", "PUT")
+		dropScopedIfMatch(req)
+		if req.Header.Get("If-Match") != `"1"` {
+			t.Error("If-Match should have been kept for a tunneled method")
+		}
+	})
+
 	checkClient(t)
 
 	t.Run("CSOMErrorHandling", func(t *testing.T) {
@@ -10,7 +10,7 @@ import (
 	"github.com/pnocera/gosip"
 )
 
-//go:generate ggen -ent Item -conf -mods Select,Expand -helpers Normalized,ToMap
+//go:generate ggen -ent Item -conf -etag -mods Select,Expand -helpers Normalized,ToMap
 
 // Item represents SharePoint Lists & Document Libraries Items API queryable object struct
 // Always use NewItem constructor instead of &Item{}
@@ -97,6 +97,16 @@ func (item *Item) Roles() *Roles {
 	return NewRoles(item.client, item.endpoint, item.config)
 }
 
+// GetUserEffectivePermissions gets effective permissions of the user on this Item
+func (item *Item) GetUserEffectivePermissions(loginName string) (*BasePermissions, error) {
+	return getUserEffectivePermissions(item.client, item.endpoint, loginName, item.config)
+}
+
+// EffectiveBasePermissions gets effective permissions of the current user on this Item
+func (item *Item) EffectiveBasePermissions() (*BasePermissions, error) {
+	return getEffectiveBasePermissions(item.client, item.endpoint, item.config)
+}
+
 // Attachments gets attachments collection for this Item
 func (item *Item) Attachments() *Attachments {
 	return NewAttachments(
@@ -106,6 +116,15 @@ func (item *Item) Attachments() *Attachments {
 	)
 }
 
+// Versions gets Versions API instance queryable collection for this Item
+func (item *Item) Versions() *ItemVersions {
+	return NewItemVersions(
+		item.client,
+		fmt.Sprintf("%s/Versions", item.endpoint),
+		item.config,
+	)
+}
+
 // ParentList gets this Item's Lists API object
 func (item *Item) ParentList() *List {
 	return NewList(
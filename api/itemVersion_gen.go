@@ -0,0 +1,28 @@
+// Code generated by `ggen -ent ItemVersion -conf -mods Select,Expand -helpers Normalized`; DO NOT EDIT.
+
+package api
+
+// Conf receives custom request config definition, e.g. custom headers, custom OData mod
+func (itemVersion *ItemVersion) Conf(config *RequestConfig) *ItemVersion {
+	itemVersion.config = config
+	return itemVersion
+}
+
+// Select adds $select OData modifier
+func (itemVersion *ItemVersion) Select(oDataSelect string) *ItemVersion {
+	itemVersion.modifiers.AddSelect(oDataSelect)
+	return itemVersion
+}
+
+// Expand adds $expand OData modifier
+func (itemVersion *ItemVersion) Expand(oDataExpand string) *ItemVersion {
+	itemVersion.modifiers.AddExpand(oDataExpand)
+	return itemVersion
+}
+
+/* Response helpers */
+
+// Normalized returns normalized body
+func (itemVersionResp *ItemVersionResp) Normalized() []byte {
+	return NormalizeODataItem(*itemVersionResp)
+}
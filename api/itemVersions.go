@@ -0,0 +1,246 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pnocera/gosip"
+)
+
+//go:generate ggen -ent ItemVersions -item ItemVersion -conf -coll -mods Select,Expand,Filter,Top,OrderBy -helpers Data,Normalized
+//go:generate ggen -ent ItemVersion -conf -mods Select,Expand -helpers Normalized
+
+// ItemVersions represent SharePoint List Item Versions API queryable collection struct
+// Always use NewItemVersions constructor instead of &ItemVersions{}
+type ItemVersions struct {
+	client    *gosip.SPClient
+	config    *RequestConfig
+	endpoint  string
+	modifiers *ODataMods
+}
+
+// ItemVersionsResp - item versions response type with helper processor methods
+type ItemVersionsResp []byte
+
+// NewItemVersions - ItemVersions struct constructor function
+func NewItemVersions(client *gosip.SPClient, endpoint string, config *RequestConfig) *ItemVersions {
+	return &ItemVersions{
+		client:    client,
+		endpoint:  endpoint,
+		config:    config,
+		modifiers: NewODataMods(),
+	}
+}
+
+// ToURL gets endpoint with modificators raw URL
+func (itemVersions *ItemVersions) ToURL() string {
+	return toURL(itemVersions.endpoint, itemVersions.modifiers)
+}
+
+// Get gets item versions collection response
+func (itemVersions *ItemVersions) Get() (ItemVersionsResp, error) {
+	client := NewHTTPClient(itemVersions.client)
+	return client.Get(itemVersions.ToURL(), itemVersions.config)
+}
+
+// GetByID gets an item version by its ID, e.g. 512 for 1.0
+func (itemVersions *ItemVersions) GetByID(versionID int) *ItemVersion {
+	return NewItemVersion(
+		itemVersions.client,
+		fmt.Sprintf("%s(%d)", itemVersions.endpoint, versionID),
+		itemVersions.config,
+	)
+}
+
+// GetByLabel gets an item version by its label, e.g. "1.0"
+func (itemVersions *ItemVersions) GetByLabel(label string) (*ItemVersion, error) {
+	scoped := NewItemVersions(itemVersions.client, itemVersions.endpoint, itemVersions.config)
+	data, err := scoped.Select("VersionId,VersionLabel").Get()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range data.Data() {
+		if info := v.Data(); info.Label == label {
+			return itemVersions.GetByID(info.ID), nil
+		}
+	}
+	return nil, fmt.Errorf("can't find version \"%s\"", label)
+}
+
+// DeleteByLabel deletes an item version by its label
+func (itemVersions *ItemVersions) DeleteByLabel(label string) error {
+	version, err := itemVersions.GetByLabel(label)
+	if err != nil {
+		return err
+	}
+	return version.Delete()
+}
+
+// DeleteAll deletes all item versions except the current one
+func (itemVersions *ItemVersions) DeleteAll() error {
+	scoped := NewItemVersions(itemVersions.client, itemVersions.endpoint, itemVersions.config)
+	data, err := scoped.Select("VersionId,VersionLabel,IsCurrentVersion").Get()
+	if err != nil {
+		return err
+	}
+	for _, v := range data.Data() {
+		info := v.Data()
+		if info.IsCurrentVersion {
+			continue
+		}
+		if err := itemVersions.GetByID(info.ID).Delete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreByLabel restores an item version by its label, the restored values become a new version.
+// Document library items are restored using file versions API, list items are restored
+// by writing back the version's editable fields values. Multi-value taxonomy fields are not restored.
+func (itemVersions *ItemVersions) RestoreByLabel(label string) error {
+	itemEndpoint := getPriorEndpoint(itemVersions.endpoint, "/Versions")
+	list := NewList(itemVersions.client, getPriorEndpoint(itemEndpoint, "/Items"), itemVersions.config)
+
+	listData, err := NewList(list.client, list.endpoint, list.config).Select("BaseType").Get()
+	if err != nil {
+		return err
+	}
+	if listData.Data().BaseType == 1 {
+		file := NewFile(itemVersions.client, fmt.Sprintf("%s/File", itemEndpoint), itemVersions.config)
+		return file.Versions().RestoreByLabel(label)
+	}
+
+	version, err := itemVersions.GetByLabel(label)
+	if err != nil {
+		return err
+	}
+	versionData, err := version.Get()
+	if err != nil {
+		return err
+	}
+	fields, err := list.getTransferFields()
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(versionRestorePayload(fields, versionData.Data().Values))
+	if err != nil {
+		return err
+	}
+	_, err = NewItem(itemVersions.client, itemEndpoint, itemVersions.config).Update(body)
+	return err
+}
+
+/* Item version */
+
+// ItemVersion represents SharePoint List Item Version API queryable object struct
+// Always use NewItemVersion constructor instead of &ItemVersion{}
+type ItemVersion struct {
+	client    *gosip.SPClient
+	config    *RequestConfig
+	endpoint  string
+	modifiers *ODataMods
+}
+
+// ItemVersionResp - item version response type with helper processor methods
+type ItemVersionResp []byte
+
+// NewItemVersion - ItemVersion struct constructor function
+func NewItemVersion(client *gosip.SPClient, endpoint string, config *RequestConfig) *ItemVersion {
+	return &ItemVersion{
+		client:    client,
+		endpoint:  endpoint,
+		config:    config,
+		modifiers: NewODataMods(),
+	}
+}
+
+// ToURL gets endpoint with modificators raw URL
+func (itemVersion *ItemVersion) ToURL() string {
+	return toURL(itemVersion.endpoint, itemVersion.modifiers)
+}
+
+// Get gets item version data object
+func (itemVersion *ItemVersion) Get() (ItemVersionResp, error) {
+	client := NewHTTPClient(itemVersion.client)
+	return client.Get(itemVersion.ToURL(), itemVersion.config)
+}
+
+// Delete deletes this item version
+func (itemVersion *ItemVersion) Delete() error {
+	client := NewHTTPClient(itemVersion.client)
+	_, err := client.Delete(itemVersion.endpoint, itemVersion.config)
+	return err
+}
+
+/* Response helpers */
+
+// Data response helper
+func (itemVersionResp *ItemVersionResp) Data() *VersionInfo {
+	return parseVersionInfo(*itemVersionResp)
+}
+
+// versionRestorePayload builds item update payload from version values for editable fields
+func versionRestorePayload(fields []*transferField, values map[string]interface{}) map[string]interface{} {
+	payload := map[string]interface{}{}
+	lookupIDs := func(value interface{}) []interface{} {
+		ids := []interface{}{}
+		if arr, ok := value.([]interface{}); ok {
+			for _, v := range arr {
+				if m, ok := v.(map[string]interface{}); ok {
+					ids = append(ids, m["LookupId"])
+				}
+			}
+		}
+		return ids
+	}
+	for _, field := range fields {
+		if field.Hidden || field.ReadOnlyField {
+			continue
+		}
+		name := field.InternalName
+		value, ok := values[name]
+		if !ok {
+			value, ok = values["OData_"+name] // internal names starting with "_" are prefixed in version payloads
+		}
+		if !ok {
+			continue
+		}
+		switch field.TypeAsString {
+		case "Computed", "Counter", "Attachments", "File", "TaxonomyFieldTypeMulti":
+			continue
+		case "Lookup", "User":
+			payload[name+"Id"] = nil
+			if m, ok := value.(map[string]interface{}); ok {
+				payload[name+"Id"] = m["LookupId"]
+			}
+		case "LookupMulti", "UserMulti":
+			payload[name+"Id"] = map[string]interface{}{"results": lookupIDs(value)}
+		case "MultiChoice":
+			if value == nil {
+				value = []interface{}{}
+			}
+			payload[name] = map[string]interface{}{"results": value}
+		case "URL":
+			if m, ok := value.(map[string]interface{}); ok {
+				payload[name] = map[string]interface{}{
+					"__metadata":  map[string]string{"type": "SP.FieldUrlValue"},
+					"Url":         m["Url"],
+					"Description": m["Description"],
+				}
+			}
+		case "TaxonomyFieldType":
+			if m, ok := value.(map[string]interface{}); ok {
+				payload[name] = map[string]interface{}{
+					"__metadata": map[string]string{"type": "SP.Taxonomy.TaxonomyFieldValue"},
+					"Label":      m["Label"],
+					"TermGuid":   m["TermGuid"],
+					"WssId":      m["WssId"],
+				}
+			}
+		default:
+			payload[name] = value
+		}
+	}
+	return payload
+}
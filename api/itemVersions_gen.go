@@ -0,0 +1,90 @@
+// Code generated by `ggen -ent ItemVersions -item ItemVersion -conf -coll -mods Select,Expand,Filter,Top,OrderBy -helpers Data,Normalized`; DO NOT EDIT.
+
+package api
+
+// Conf receives custom request config definition, e.g. custom headers, custom OData mod
+func (itemVersions *ItemVersions) Conf(config *RequestConfig) *ItemVersions {
+	itemVersions.config = config
+	return itemVersions
+}
+
+// Select adds $select OData modifier
+func (itemVersions *ItemVersions) Select(oDataSelect string) *ItemVersions {
+	itemVersions.modifiers.AddSelect(oDataSelect)
+	return itemVersions
+}
+
+// Expand adds $expand OData modifier
+func (itemVersions *ItemVersions) Expand(oDataExpand string) *ItemVersions {
+	itemVersions.modifiers.AddExpand(oDataExpand)
+	return itemVersions
+}
+
+// Filter adds $filter OData modifier
+func (itemVersions *ItemVersions) Filter(oDataFilter string) *ItemVersions {
+	itemVersions.modifiers.AddFilter(oDataFilter)
+	return itemVersions
+}
+
+// Top adds $top OData modifier
+func (itemVersions *ItemVersions) Top(oDataTop int) *ItemVersions {
+	itemVersions.modifiers.AddTop(oDataTop)
+	return itemVersions
+}
+
+// OrderBy adds $orderby OData modifier
+func (itemVersions *ItemVersions) OrderBy(oDataOrderBy string, ascending bool) *ItemVersions {
+	itemVersions.modifiers.AddOrderBy(oDataOrderBy, ascending)
+	return itemVersions
+}
+
+/* Response helpers */
+
+// Data response helper
+func (itemVersionsResp *ItemVersionsResp) Data() []ItemVersionResp {
+	collection, _ := normalizeODataCollection(*itemVersionsResp)
+	itemVersions := []ItemVersionResp{}
+	for _, item := range collection {
+		itemVersions = append(itemVersions, ItemVersionResp(item))
+	}
+	return itemVersions
+}
+
+// Normalized returns normalized body
+func (itemVersionsResp *ItemVersionsResp) Normalized() []byte {
+	normalized, _ := NormalizeODataCollection(*itemVersionsResp)
+	return normalized
+}
+
+/* Pagination helpers */
+
+// ItemVersionsPager - ItemVersions collection pages iterator
+type ItemVersionsPager struct {
+	*Pager
+}
+
+// Pager gets ItemVersions collection pages iterator, the iteration follows OData next page links
+func (itemVersions *ItemVersions) Pager() *ItemVersionsPager {
+	return &ItemVersionsPager{NewPager(itemVersions.client, itemVersions.ToURL(), itemVersions.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *ItemVersionsPager) Prefetch(enabled bool) *ItemVersionsPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *ItemVersionsPager) Page() ItemVersionsResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (itemVersionsResp *ItemVersionsResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*itemVersionsResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (itemVersionsResp *ItemVersionsResp) HasNextPage() bool {
+	return itemVersionsResp.NextPageURL() != ""
+}
@@ -1,4 +1,4 @@
-// Code generated by `ggen -ent Item -conf -mods Select,Expand -helpers Normalized,ToMap`; DO NOT EDIT.
+// Code generated by `ggen -ent Item -conf -etag -mods Select,Expand -helpers Normalized,ToMap`; DO NOT EDIT.
 
 package api
 
@@ -38,3 +38,18 @@ func (itemResp *ItemResp) ToMap() map[string]interface{} {
 	_ = json.Unmarshal(data, &res)
 	return res
 }
+
+/* Optimistic concurrency helpers */
+
+// IfMatch gets a copy of the entity with the expected ETag for Update and Delete requests,
+// the requests fail with *PreconditionFailedError when the entity was modified since the ETag was received
+func (item *Item) IfMatch(etag string) *Item {
+	scoped := *item
+	scoped.config = withIfMatch(item.config, etag)
+	return &scoped
+}
+
+// ETag gets entity ETag from the response metadata
+func (itemResp *ItemResp) ETag() string {
+	return getODataETag(*itemResp)
+}
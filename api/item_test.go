@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -70,6 +71,26 @@ func TestItem(t *testing.T) {
 		}
 	})
 
+	t.Run("UpdateIfMatch", func(t *testing.T) {
+		item, err := list.Items().GetByID(3).Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		etag := item.ETag()
+		if etag == "" {
+			t.Fatal("can't get item ETag")
+		}
+		body := []byte(`{"Title":"Updated Item 3"}`)
+		if _, err := list.Items().GetByID(3).IfMatch(etag).Update(body); err != nil {
+			t.Error(err)
+		}
+		_, err = list.Items().GetByID(3).IfMatch(etag).Update(body)
+		var preconditionErr *PreconditionFailedError
+		if !errors.As(err, &preconditionErr) {
+			t.Errorf("precondition failed error is expected, got %v", err)
+		}
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		if err := list.Items().GetByID(1).Delete(); err != nil {
 			t.Error(err)
@@ -178,13 +178,3 @@ func (items *Items) GetPaged() (*ItemsPage, error) {
 	}
 	return res, nil
 }
-
-// NextPageURL gets next page OData collection
-func (itemsResp *ItemsResp) NextPageURL() string {
-	return getODataCollectionNextPageURL(*itemsResp)
-}
-
-// HasNextPage returns is true if next page exists
-func (itemsResp *ItemsResp) HasNextPage() bool {
-	return itemsResp.NextPageURL() != ""
-}
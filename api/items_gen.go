@@ -73,3 +73,36 @@ func (itemsResp *ItemsResp) ToMap() []map[string]interface{} {
 	_ = json.Unmarshal(data, &res)
 	return res
 }
+
+/* Pagination helpers */
+
+// ItemsPager - Items collection pages iterator
+type ItemsPager struct {
+	*Pager
+}
+
+// Pager gets Items collection pages iterator, the iteration follows OData next page links
+func (items *Items) Pager() *ItemsPager {
+	return &ItemsPager{NewPager(items.client, items.ToURL(), items.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *ItemsPager) Prefetch(enabled bool) *ItemsPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *ItemsPager) Page() ItemsResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (itemsResp *ItemsResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*itemsResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (itemsResp *ItemsResp) HasNextPage() bool {
+	return itemsResp.NextPageURL() != ""
+}
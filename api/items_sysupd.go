@@ -0,0 +1,252 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pnocera/gosip/csom"
+)
+
+// SystemUpdateOptions SystemUpdate method options
+type SystemUpdateOptions struct {
+	CheckInComment   string         // check in comment, REST mode only
+	DateLayout       string         // Go time layout of the web's regional settings dates format, default is "1/2/2006 3:04 PM" (en-US)
+	TimeZone         *time.Location // the web's regional settings time zone to convert dates to, UTC when nil
+	UseCSOM          bool           // forces CSOM mode, by default CSOM is only used when REST ValidateUpdateListItem overload is not available
+	OverwriteVersion bool           // CSOM mode only, use UpdateOverwriteVersion instead of SystemUpdate
+}
+
+// SystemUpdate updates this item without creating a new version.
+// ValidateUpdateListItem with bNewDocumentUpdate is used by default, it keeps Author and Created
+// but sets Editor and Modified to the current user and time unless these fields are provided in `values`.
+// CSOM mode (SystemUpdate or UpdateOverwriteVersion) preserves Author, Editor, Created and Modified
+// unless provided, use UseCSOM option when Editor and Modified must be kept.
+// Supported `values` types: string, int, float64, bool, time.Time (UTC), []string (multi choice),
+// field value types (FieldUserValue, FieldLookupValues, FieldURLValue, etc.) and nil to clear a value.
+// People values can be provided either by ID or by login name.
+// CSOM mode is also a fallback for the environments where the REST overload is missing.
+func (item *Item) SystemUpdate(values map[string]interface{}, options *SystemUpdateOptions) (UpdateValidateResp, error) {
+	if options == nil {
+		options = &SystemUpdateOptions{}
+	}
+	if options.UseCSOM {
+		return nil, item.systemUpdateCSOM(values, options)
+	}
+
+	formValues := map[string]string{}
+	for name, value := range values {
+		formValue, err := item.systemUpdateFormValue(value, options)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		formValues[name] = formValue
+	}
+
+	res, err := item.UpdateValidate(formValues, &ValidateUpdateOptions{
+		NewDocumentUpdate: true,
+		CheckInComment:    options.CheckInComment,
+	})
+	if err != nil && isMissingValidateUpdateOverload(err) {
+		return nil, item.systemUpdateCSOM(values, options)
+	}
+	return res, err
+}
+
+// isMissingValidateUpdateOverload checks if an error is caused by the lack of ValidateUpdateListItem overload
+func isMissingValidateUpdateOverload(err error) bool {
+	msg := err.Error()
+	if !strings.HasPrefix(msg, "unable to request api: 400") && !strings.HasPrefix(msg, "unable to request api: 404") {
+		return false
+	}
+	return strings.Contains(msg, "bNewDocumentUpdate") || strings.Contains(msg, "ValidateUpdateListItem")
+}
+
+// systemUpdateFormValue formats a typed value to ValidateUpdateListItem form value string
+func (item *Item) systemUpdateFormValue(value interface{}, options *SystemUpdateOptions) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		if v {
+			return "1", nil
+		}
+		return "0", nil
+	case time.Time:
+		layout := options.DateLayout
+		if layout == "" {
+			layout = "1/2/2006 3:04 PM"
+		}
+		loc := options.TimeZone
+		if loc == nil {
+			loc = time.UTC
+		}
+		return v.In(loc).Format(layout), nil
+	case []string:
+		return strings.Join(v, ";#"), nil
+	case FieldUserValue:
+		return item.systemUpdateUsers(FieldUserValues{v})
+	case FieldUserValues:
+		return item.systemUpdateUsers(v)
+	case FieldFormValuer:
+		return v.FormValue(), nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", value)
+}
+
+// systemUpdateUsers formats people field value, resolving login names for users provided by ID
+func (item *Item) systemUpdateUsers(users FieldUserValues) (string, error) {
+	resolved := FieldUserValues{}
+	for _, user := range users {
+		loginName, err := item.resolveLoginName(user)
+		if err != nil {
+			return "", err
+		}
+		resolved = append(resolved, FieldUserValue{LoginName: loginName})
+	}
+	return resolved.FormValue(), nil
+}
+
+// resolveLoginName gets user login name by its ID when the login is not provided
+func (item *Item) resolveLoginName(user FieldUserValue) (string, error) {
+	if user.LoginName != "" {
+		return user.LoginName, nil
+	}
+	if user.ID == 0 {
+		return "", fmt.Errorf("either user ID or login name should be provided")
+	}
+	web := NewWeb(item.client, getPriorEndpoint(item.endpoint, "/_api")+"/_api/Web", item.config)
+	data, err := web.SiteUsers().GetByID(user.ID).Select("LoginName").Get()
+	if err != nil {
+		return "", err
+	}
+	return data.Data().LoginName, nil
+}
+
+// systemUpdateCSOM updates item with CSOM SystemUpdate or UpdateOverwriteVersion method
+func (item *Item) systemUpdateCSOM(values map[string]interface{}, options *SystemUpdateOptions) error {
+	scoped := NewItem(item.client, item.endpoint, item.config)
+	itemR, err := scoped.Select("Id").Get()
+	if err != nil {
+		return err
+	}
+	list := NewList(item.client, getPriorEndpoint(item.endpoint, "/Items"), item.config)
+	listR, err := list.Select("Id").Get()
+	if err != nil {
+		return err
+	}
+
+	b := csom.NewBuilder()
+	b.AddObject(csom.NewObjectProperty("Web"), nil)
+	b.AddObject(csom.NewObjectProperty("Lists"), nil)
+	b.AddObject(csom.NewObjectMethod("GetById", []string{`<Parameter Type="String">` + listR.Data().ID + `</Parameter>`}), nil)
+	itemObj, _ := b.AddObject(csom.NewObjectMethod("GetItemById", []string{`<Parameter Type="Number">` + strconv.Itoa(itemR.Data().ID) + `</Parameter>`}), nil)
+
+	for name, value := range values {
+		param, err := item.systemUpdateCSOMParameter(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		b.AddAction(csom.NewActionMethod("SetFieldValue", []string{
+			`<Parameter Type="String">` + html.EscapeString(name) + `</Parameter>`,
+			param,
+		}), itemObj)
+	}
+
+	method := "SystemUpdate"
+	if options.OverwriteVersion {
+		method = "UpdateOverwriteVersion"
+	}
+	b.AddAction(csom.NewActionMethod(method, []string{}), itemObj)
+
+	csomPkg, err := b.Compile()
+	if err != nil {
+		return err
+	}
+
+	client := NewHTTPClient(item.client)
+	_, err = client.ProcessQuery(item.endpoint, bytes.NewBuffer([]byte(csomPkg)), item.config)
+	return err
+}
+
+// systemUpdateCSOMParameter formats a typed value to CSOM SetFieldValue parameter
+func (item *Item) systemUpdateCSOMParameter(value interface{}) (string, error) {
+	lookupValue := func(tag string, typeID string, id int) string {
+		return `<` + tag + ` TypeId="` + typeID + `"><Property Name="LookupId" Type="Int32">` + strconv.Itoa(id) + `</Property><Property Name="LookupValue" Type="Null" /></` + tag + `>`
+	}
+	lookupTypeID := "{f1d34cc0-9b50-4a78-be78-d5facfcccfb7}"
+	userTypeID := "{c956ab54-16bd-4c18-89d2-996f57282a6f}"
+	userID := func(user FieldUserValue) (int, error) {
+		if user.ID != 0 {
+			return user.ID, nil
+		}
+		web := NewWeb(item.client, getPriorEndpoint(item.endpoint, "/_api")+"/_api/Web", item.config)
+		u, err := web.EnsureUser(user.LoginName)
+		if err != nil {
+			return 0, err
+		}
+		return u.ID, nil
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return `<Parameter Type="Null" />`, nil
+	case string:
+		return `<Parameter Type="String">` + html.EscapeString(v) + `</Parameter>`, nil
+	case int:
+		return `<Parameter Type="Number">` + strconv.Itoa(v) + `</Parameter>`, nil
+	case float64:
+		return `<Parameter Type="Number">` + strconv.FormatFloat(v, 'f', -1, 64) + `</Parameter>`, nil
+	case bool:
+		return `<Parameter Type="Boolean">` + strconv.FormatBool(v) + `</Parameter>`, nil
+	case time.Time:
+		return `<Parameter Type="DateTime">` + v.UTC().Format(time.RFC3339) + `</Parameter>`, nil
+	case []string:
+		return item.systemUpdateCSOMParameter(FieldMultiChoiceValue(v))
+	case FieldMultiChoiceValue:
+		items := ""
+		for _, s := range v {
+			items += `<Object Type="String">` + html.EscapeString(s) + `</Object>`
+		}
+		return `<Parameter Type="Array">` + items + `</Parameter>`, nil
+	case FieldLookupValue:
+		return lookupValue("Parameter", lookupTypeID, v.ID), nil
+	case FieldLookupValues:
+		items := ""
+		for _, l := range v {
+			items += lookupValue("Object", lookupTypeID, l.ID)
+		}
+		return `<Parameter Type="Array">` + items + `</Parameter>`, nil
+	case FieldUserValue:
+		id, err := userID(v)
+		if err != nil {
+			return "", err
+		}
+		return lookupValue("Parameter", userTypeID, id), nil
+	case FieldUserValues:
+		items := ""
+		for _, u := range v {
+			id, err := userID(u)
+			if err != nil {
+				return "", err
+			}
+			items += lookupValue("Object", userTypeID, id)
+		}
+		return `<Parameter Type="Array">` + items + `</Parameter>`, nil
+	case FieldURLValue:
+		return `<Parameter TypeId="{fa8b44af-7b43-43f2-904a-bd319497011e}">` +
+			`<Property Name="Description" Type="String">` + html.EscapeString(v.Description) + `</Property>` +
+			`<Property Name="Url" Type="String">` + html.EscapeString(v.URL) + `</Property>` +
+			`</Parameter>`, nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", value)
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestItemsSystemUpdate(t *testing.T) {
+
+	t.Run("systemUpdateFormValue", func(t *testing.T) {
+		item := &Item{}
+		options := &SystemUpdateOptions{}
+		date := time.Date(2020, 1, 2, 15, 4, 0, 0, time.UTC)
+		cases := []struct {
+			value    interface{}
+			expected string
+		}{
+			{"text", "text"},
+			{42, "42"},
+			{true, "1"},
+			{date, "1/2/2020 3:04 PM"},
+			{[]string{"A", "B"}, "A;#B"},
+			{FieldLookupValue{ID: 3}, "3"},
+			{FieldLookupValues{{ID: 3}, {ID: 4}}, "3;#;#4"},
+			{FieldUserValue{LoginName: "user"}, `[{"Key":"user"}]`},
+			{nil, ""},
+		}
+		for _, c := range cases {
+			res, err := item.systemUpdateFormValue(c.value, options)
+			if err != nil {
+				t.Error(err)
+			}
+			if res != c.expected {
+				t.Errorf("incorrect form value for %T, expected \"%s\", got \"%s\"", c.value, c.expected, res)
+			}
+		}
+		if _, err := item.systemUpdateFormValue(struct{}{}, options); err == nil {
+			t.Error("failed to detect unsupported value type")
+		}
+	})
+
+	t.Run("systemUpdateCSOMParameter", func(t *testing.T) {
+		item := &Item{}
+		param, err := item.systemUpdateCSOMParameter(FieldLookupValues{{ID: 3}, {ID: 4}})
+		if err != nil {
+			t.Error(err)
+		}
+		if strings.Count(param, `<Property Name="LookupId" Type="Int32">`) != 2 {
+			t.Errorf("incorrect lookup parameter: %s", param)
+		}
+		param, _ = item.systemUpdateCSOMParameter("<b>")
+		if param != `<Parameter Type="String">&lt;b&gt;</Parameter>` {
+			t.Errorf("incorrect string parameter: %s", param)
+		}
+	})
+
+	t.Run("isMissingValidateUpdateOverload", func(t *testing.T) {
+		err := fmt.Errorf("unable to request api: 400 Bad Request :: The parameter bNewDocumentUpdate does not exist in method ValidateUpdateListItem.")
+		if !isMissingValidateUpdateOverload(err) {
+			t.Error("missing overload was not detected")
+		}
+		if isMissingValidateUpdateOverload(fmt.Errorf("unable to request api: 403 Forbidden")) {
+			t.Error("wrong missing overload detection")
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	newListTitle := strings.Replace(uuid.New().String(), "-", "", -1)
+	if _, err := web.Lists().Add(newListTitle, nil); err != nil {
+		t.Error(err)
+	}
+	list := web.Lists().GetByTitle(newListTitle)
+
+	t.Run("SystemUpdate", func(t *testing.T) {
+		if envCode == "2013" {
+			t.Skip("is not supported with SP 2013")
+		}
+		item, err := list.Items().Add([]byte(`{"Title":"Item"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		created := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+		values := map[string]interface{}{
+			"Title":    "Updated",
+			"Created":  created,
+			"Modified": created,
+		}
+		if _, err := list.Items().GetByID(item.Data().ID).SystemUpdate(values, nil); err != nil {
+			t.Fatal(err)
+		}
+		data, err := list.Items().GetByID(item.Data().ID).Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.Data().Title != "Updated" {
+			t.Error("item was not updated")
+		}
+		if data.Data().Created.Year() != 2019 {
+			t.Error("item creation date was not preserved")
+		}
+	})
+
+	t.Run("SystemUpdateCSOM", func(t *testing.T) {
+		item, err := list.Items().Add([]byte(`{"Title":"Item"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		values := map[string]interface{}{"Title": "Updated"}
+		options := &SystemUpdateOptions{UseCSOM: true}
+		if _, err := list.Items().GetByID(item.Data().ID).SystemUpdate(values, options); err != nil {
+			t.Error(err)
+		}
+	})
+
+	if err := list.Delete(); err != nil {
+		t.Error(err)
+	}
+
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/pnocera/gosip"
 )
 
-//go:generate ggen -ent List -conf -mods Select,Expand -helpers Normalized
+//go:generate ggen -ent List -conf -etag -mods Select,Expand -helpers Normalized
 
 // List represents SharePoint List API queryable object struct
 // Always use NewList constructor instead of &List{}
@@ -279,6 +279,16 @@ func (list *List) Roles() *Roles {
 	return NewRoles(list.client, list.endpoint, list.config)
 }
 
+// GetUserEffectivePermissions gets effective permissions of the user on this List
+func (list *List) GetUserEffectivePermissions(loginName string) (*BasePermissions, error) {
+	return getUserEffectivePermissions(list.client, list.endpoint, loginName, list.config)
+}
+
+// EffectiveBasePermissions gets effective permissions of the current user on this List
+func (list *List) EffectiveBasePermissions() (*BasePermissions, error) {
+	return getEffectiveBasePermissions(list.client, list.endpoint, list.config)
+}
+
 // ContextInfo gets context info for a web of current list
 func (list *List) ContextInfo() (*ContextInfo, error) {
 	return NewContext(list.client, list.ToURL(), list.config).Get()
@@ -0,0 +1,301 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListExportOptions provides optional settings for ExportCSV and ExportJSONL methods
+type ListExportOptions struct {
+	Fields    []string // fields internal names to export, all visible non-computed fields are exported when empty
+	Filter    string   // OData $filter applied to the exported items
+	UserProp  string   // user property used as a people field value: Name (login name, default), EMail or Title
+	UseTitles bool     // use fields display names in CSV header instead of internal names
+	PageSize  int      // items page size, default is 2000
+}
+
+// transferField - list field metadata relevant for items export and import
+type transferField struct {
+	InternalName  string `json:"InternalName"`
+	Title         string `json:"Title"`
+	TypeAsString  string `json:"TypeAsString"`
+	Hidden        bool   `json:"Hidden"`
+	ReadOnlyField bool   `json:"ReadOnlyField"`
+	LookupField   string `json:"LookupField"`
+}
+
+// multiValueSeparator is used to join multiple values in a single CSV cell
+const multiValueSeparator = ";#"
+
+// ExportCSV writes this list items to a CSV stream, the first row is a header with fields names.
+// Complex field values are formatted based on the field type:
+// lookups as `ID;#Value`, people as login names, taxonomy as `Label|TermGuid`,
+// hyperlinks as `URL, Description`, dates as RFC3339 UTC, multiple values are joined with `;#`.
+func (list *List) ExportCSV(writer io.Writer, options *ListExportOptions) error {
+	if options == nil {
+		options = &ListExportOptions{}
+	}
+	fields, err := list.getExportFields(options)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(writer)
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = field.InternalName
+		if options.UseTitles {
+			header[i] = field.Title
+		}
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	err = list.exportItems(fields, options, func(row map[string]interface{}) error {
+		record := make([]string, len(fields))
+		for i, field := range fields {
+			record[i] = transferValueToString(row[field.InternalName])
+		}
+		return w.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// ExportJSONL writes this list items to a JSON Lines stream, one JSON object per item with fields internal names as keys.
+// Field values are formatted in the same way as in ExportCSV, except numbers and booleans
+// which are kept native and multiple values which are exported as arrays.
+func (list *List) ExportJSONL(writer io.Writer, options *ListExportOptions) error {
+	if options == nil {
+		options = &ListExportOptions{}
+	}
+	fields, err := list.getExportFields(options)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(writer)
+	return list.exportItems(fields, options, func(row map[string]interface{}) error {
+		return encoder.Encode(row)
+	})
+}
+
+// exportItems pages through list items and calls `process` with each item formatted values
+func (list *List) exportItems(fields []*transferField, options *ListExportOptions, process func(row map[string]interface{}) error) error {
+	var selects []string
+	var expands []string
+	for _, field := range fields {
+		s, e := transferFieldSelect(field)
+		selects = append(selects, s...)
+		expands = append(expands, e...)
+	}
+
+	pageSize := options.PageSize
+	if pageSize == 0 {
+		pageSize = 2000
+	}
+
+	items := list.Items().Select(strings.Join(selects, ",")).Top(pageSize)
+	if len(expands) > 0 {
+		items.Expand(strings.Join(expands, ","))
+	}
+	if options.Filter != "" {
+		items.Filter(options.Filter)
+	}
+
+	page, err := items.GetPaged()
+	for {
+		if err != nil {
+			return err
+		}
+		for _, item := range page.Items.Data() {
+			data := normalizeMultiLookups(NormalizeODataItem(item))
+			raw := map[string]interface{}{}
+			if err := json.Unmarshal(data, &raw); err != nil {
+				return err
+			}
+			row := map[string]interface{}{}
+			for _, field := range fields {
+				row[field.InternalName] = formatTransferValue(field, raw[field.InternalName], options.UserProp)
+			}
+			if err := process(row); err != nil {
+				return err
+			}
+		}
+		if !page.HasNextPage() {
+			return nil
+		}
+		page, err = page.GetNextPage()
+	}
+}
+
+// getExportFields resolves list fields metadata for the export
+func (list *List) getExportFields(options *ListExportOptions) ([]*transferField, error) {
+	fields, err := list.getTransferFields()
+	if err != nil {
+		return nil, err
+	}
+	if len(options.Fields) == 0 {
+		var res []*transferField
+		for _, field := range fields {
+			if field.Hidden || field.TypeAsString == "Computed" || field.TypeAsString == "Attachments" {
+				continue
+			}
+			res = append(res, field)
+		}
+		return res, nil
+	}
+	res := make([]*transferField, len(options.Fields))
+	for i, name := range options.Fields {
+		field := findTransferField(fields, name)
+		if field == nil {
+			return nil, fmt.Errorf("can't find field \"%s\" in the list", name)
+		}
+		res[i] = field
+	}
+	return res, nil
+}
+
+// getTransferFields gets this list fields metadata
+func (list *List) getTransferFields() ([]*transferField, error) {
+	data, err := list.Fields().Get()
+	if err != nil {
+		return nil, err
+	}
+	var fields []*transferField
+	for _, f := range data.Data() {
+		field := &transferField{}
+		if err := json.Unmarshal(f.Normalized(), &field); err != nil {
+			return nil, fmt.Errorf("unable to parse the response: %w", err)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// findTransferField finds a field by its internal or display name
+func findTransferField(fields []*transferField, name string) *transferField {
+	for _, field := range fields {
+		if field.InternalName == name {
+			return field
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.Title, name) {
+			return field
+		}
+	}
+	return nil
+}
+
+// transferFieldSelect gets $select and $expand parts required to read a field value
+func transferFieldSelect(field *transferField) ([]string, []string) {
+	name := field.InternalName
+	switch field.TypeAsString {
+	case "Lookup", "LookupMulti":
+		lookupField := field.LookupField
+		if lookupField == "" {
+			lookupField = "Title"
+		}
+		return []string{name + "/Id", name + "/" + lookupField}, []string{name}
+	case "User", "UserMulti":
+		return []string{name + "/Id", name + "/Name", name + "/EMail", name + "/Title"}, []string{name}
+	}
+	return []string{name}, nil
+}
+
+// formatTransferValue formats raw REST field value to an export friendly representation
+func formatTransferValue(field *transferField, value interface{}, userProp string) interface{} {
+	if value == nil {
+		return nil
+	}
+	switch field.TypeAsString {
+	case "Lookup", "User", "TaxonomyFieldType":
+		return formatTransferSingleValue(field, value, userProp)
+	case "LookupMulti", "UserMulti", "TaxonomyFieldTypeMulti", "MultiChoice":
+		values, ok := value.([]interface{})
+		if !ok {
+			return formatTransferSingleValue(field, value, userProp)
+		}
+		res := []string{}
+		for _, v := range values {
+			res = append(res, formatTransferSingleValue(field, v, userProp))
+		}
+		return res
+	case "URL":
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return transferValueToString(value)
+		}
+		url := transferValueToString(v["Url"])
+		if desc := transferValueToString(v["Description"]); desc != "" && desc != url {
+			return url + ", " + desc
+		}
+		return url
+	case "DateTime":
+		if s, ok := value.(string); ok {
+			if d, err := time.Parse(time.RFC3339, s); err == nil {
+				return d.UTC().Format(time.RFC3339)
+			}
+		}
+	case "Boolean", "Number", "Currency", "Integer", "Counter":
+		return value
+	}
+	return transferValueToString(value)
+}
+
+// formatTransferSingleValue formats a single value of a (multi) lookup, people, taxonomy or choice field
+func formatTransferSingleValue(field *transferField, value interface{}, userProp string) string {
+	v, ok := value.(map[string]interface{})
+	if !ok {
+		return transferValueToString(value)
+	}
+	switch field.TypeAsString {
+	case "Lookup", "LookupMulti":
+		lookupField := field.LookupField
+		if lookupField == "" {
+			lookupField = "Title"
+		}
+		return transferValueToString(v["Id"]) + multiValueSeparator + transferValueToString(v[lookupField])
+	case "User", "UserMulti":
+		if userProp == "" {
+			userProp = "Name"
+		}
+		return transferValueToString(v[userProp])
+	case "TaxonomyFieldType", "TaxonomyFieldTypeMulti":
+		return transferValueToString(v["Label"]) + "|" + transferValueToString(v["TermGuid"])
+	}
+	return transferValueToString(value)
+}
+
+// transferValueToString converts formatted value to a string, multiple values are joined with `;#`
+func transferValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		return strings.Join(v, multiValueSeparator)
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, val := range v {
+			values[i] = transferValueToString(val)
+		}
+		return strings.Join(values, multiValueSeparator)
+	}
+	return fmt.Sprintf("%v", value)
+}
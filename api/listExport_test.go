@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestListExport(t *testing.T) {
+
+	t.Run("formatTransferValue", func(t *testing.T) {
+		cases := []struct {
+			field    *transferField
+			value    interface{}
+			expected string
+		}{
+			{&transferField{TypeAsString: "Lookup", LookupField: "Title"}, map[string]interface{}{"Id": 3.0, "Title": "Three"}, "3;#Three"},
+			{&transferField{TypeAsString: "LookupMulti"}, []interface{}{map[string]interface{}{"Id": 1.0, "Title": "A"}, map[string]interface{}{"Id": 2.0, "Title": "B"}}, "1;#A;#2;#B"},
+			{&transferField{TypeAsString: "User"}, map[string]interface{}{"Id": 7.0, "Name": "i:0#.f|membership|user@contoso.com"}, "i:0#.f|membership|user@contoso.com"},
+			{&transferField{TypeAsString: "TaxonomyFieldTypeMulti"}, []interface{}{map[string]interface{}{"Label": "A", "TermGuid": "g1"}, map[string]interface{}{"Label": "B", "TermGuid": "g2"}}, "A|g1;#B|g2"},
+			{&transferField{TypeAsString: "URL"}, map[string]interface{}{"Url": "https://contoso.com", "Description": "Contoso"}, "https://contoso.com, Contoso"},
+			{&transferField{TypeAsString: "DateTime"}, "2020-01-02T10:00:00+02:00", "2020-01-02T08:00:00Z"},
+			{&transferField{TypeAsString: "MultiChoice"}, []interface{}{"A", "B"}, "A;#B"},
+			{&transferField{TypeAsString: "Number"}, 1.5, "1.5"},
+			{&transferField{TypeAsString: "Boolean"}, true, "true"},
+			{&transferField{TypeAsString: "Text"}, nil, ""},
+		}
+		for _, c := range cases {
+			res := transferValueToString(formatTransferValue(c.field, c.value, ""))
+			if res != c.expected {
+				t.Errorf("incorrect %s value, expected \"%s\", got \"%s\"", c.field.TypeAsString, c.expected, res)
+			}
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	newListTitle := strings.Replace(uuid.New().String(), "-", "", -1)
+	if _, err := web.Lists().Add(newListTitle, nil); err != nil {
+		t.Error(err)
+	}
+	list := web.Lists().GetByTitle(newListTitle)
+
+	for i := 1; i <= 3; i++ {
+		if _, err := list.Items().Add([]byte(fmt.Sprintf(`{"Title":"Item %d"}`, i))); err != nil {
+			t.Error(err)
+		}
+	}
+
+	t.Run("ExportCSV", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := list.ExportCSV(buf, &ListExportOptions{Fields: []string{"ID", "Title", "Author"}}); err != nil {
+			t.Fatal(err)
+		}
+		records, err := csv.NewReader(buf).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 4 {
+			t.Errorf("unexpected rows number, expected 4, got %d", len(records))
+		}
+	})
+
+	t.Run("ExportJSONL", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		if err := list.ExportJSONL(buf, nil); err != nil {
+			t.Fatal(err)
+		}
+		if len(strings.Split(strings.TrimSpace(buf.String()), "\n")) != 3 {
+			t.Error("unexpected lines number")
+		}
+	})
+
+	if err := list.Delete(); err != nil {
+		t.Error(err)
+	}
+
+}
@@ -0,0 +1,465 @@
+package api
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ListImportOptions provides optional settings for ImportCSV and ImportJSONL methods
+type ListImportOptions struct {
+	Mapping           map[string]string // column name to field internal name mapping, columns matching fields internal or display names are mapped automatically
+	KeyField          string            // field internal name used to match existing items, existing items are updated and new ones are added, always adds items when empty
+	Concurrency       int               // number of rows processed in parallel, default is 5
+	DateLayout        string            // Go time layout used to convert RFC3339 dates to the web's regional settings format, e.g. "1/2/2006 3:04 PM", dates are passed as is when empty
+	TimeZone          *time.Location    // the web's regional settings time zone used with DateLayout, UTC when nil
+	FolderPath        string            // folder relative URL where new items are created, the list root folder when empty
+	NewDocumentUpdate bool              // passed as bNewDocumentUpdate to AddValidate and UpdateValidate
+}
+
+// ListImportReport describes items import results
+type ListImportReport struct {
+	Added   int                    // number of added items
+	Updated int                    // number of updated items
+	Failed  int                    // number of failed rows
+	Rows    []*ListImportRowResult // per-row results ordered by row number
+}
+
+// ListImportRowResult describes a single row import result
+type ListImportRowResult struct {
+	Row    int    // data row number starting from 1, header is not counted
+	Key    string // key field value when KeyField is used
+	ItemID int    // added or updated item ID
+	Action string // "add" or "update"
+	Error  error  // row processing error, nil on success
+}
+
+// listImportRow - raw values of a row to import
+type listImportRow struct {
+	num    int
+	values map[string]interface{}
+}
+
+// listImportKeys - existing items IDs by key field values, rows with the same key are processed
+// one at a time so that the duplicates update the item added by the first of them
+type listImportKeys struct {
+	mu    sync.Mutex
+	ids   map[string]int
+	locks map[string]*sync.Mutex
+}
+
+// lock locks the key and gets the ID of the item with this key, 0 when there is no such item yet
+func (keys *listImportKeys) lock(key string) int {
+	keys.mu.Lock()
+	keyLock, ok := keys.locks[key]
+	if !ok {
+		keyLock = &sync.Mutex{}
+		keys.locks[key] = keyLock
+	}
+	keys.mu.Unlock()
+
+	keyLock.Lock()
+	keys.mu.Lock()
+	defer keys.mu.Unlock()
+	return keys.ids[key]
+}
+
+// unlock records the ID of the item with the key, if any, and unlocks the key
+func (keys *listImportKeys) unlock(key string, itemID int) {
+	keys.mu.Lock()
+	if itemID != 0 {
+		keys.ids[key] = itemID
+	}
+	keyLock := keys.locks[key]
+	keys.mu.Unlock()
+	keyLock.Unlock()
+}
+
+// ImportCSV imports items from a CSV stream into this list, the first row should be a header with column names.
+// Values are expected in the format produced by ExportCSV and are written using AddValidate and UpdateValidate methods.
+// Lookup columns take `ID;#Value` pairs, item IDs alone go to a `<Field>Id` column (e.g. `ManagerId`) which
+// takes precedence over the lookup column.
+// Row level errors don't interrupt the import and are collected in the report.
+func (list *List) ImportCSV(reader io.Reader, options *ListImportOptions) (*ListImportReport, error) {
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CSV header: %w", err)
+	}
+	num := 0
+	return list.importItems(options, func() (*listImportRow, error) {
+		record, err := r.Read()
+		if err != nil {
+			return nil, err
+		}
+		num++
+		row := &listImportRow{num: num, values: map[string]interface{}{}}
+		for i, column := range header {
+			if i < len(record) {
+				row.values[column] = record[i]
+			}
+		}
+		return row, nil
+	})
+}
+
+// ImportJSONL imports items from a JSON Lines stream into this list, each line is a JSON object with column names as keys.
+// Values are expected in the format produced by ExportJSONL and are written using AddValidate and UpdateValidate methods.
+// Lookup item IDs are passed in `<Field>Id` keys in the same way as in ImportCSV.
+// Row level errors don't interrupt the import and are collected in the report.
+func (list *List) ImportJSONL(reader io.Reader, options *ListImportOptions) (*ListImportReport, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	num := 0
+	return list.importItems(options, func() (*listImportRow, error) {
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			num++
+			row := &listImportRow{num: num, values: map[string]interface{}{}}
+			if err := json.Unmarshal([]byte(line), &row.values); err != nil {
+				return nil, fmt.Errorf("unable to parse line %d: %w", num, err)
+			}
+			return row, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	})
+}
+
+// importItems reads rows with `next` until io.EOF and upserts them with bounded concurrency
+func (list *List) importItems(options *ListImportOptions, next func() (*listImportRow, error)) (*ListImportReport, error) {
+	if options == nil {
+		options = &ListImportOptions{}
+	}
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = 5
+	}
+
+	fields, err := list.getTransferFields()
+	if err != nil {
+		return nil, err
+	}
+
+	var keyField *transferField
+	keys := &listImportKeys{ids: map[string]int{}, locks: map[string]*sync.Mutex{}}
+	if options.KeyField != "" {
+		if keyField = findTransferField(fields, options.KeyField); keyField == nil {
+			return nil, fmt.Errorf("can't find key field \"%s\" in the list", options.KeyField)
+		}
+		if keys.ids, err = list.getItemsKeys(keyField); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &ListImportReport{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	rows := make(chan *listImportRow)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range rows {
+				res := list.importRow(row, fields, keyField, keys, options)
+				mu.Lock()
+				report.Rows = append(report.Rows, res)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var readErr error
+	for {
+		if list.config != nil && list.config.Context != nil && list.config.Context.Err() != nil {
+			readErr = list.config.Context.Err()
+			break
+		}
+		row, err := next()
+		if err != nil {
+			if err != io.EOF {
+				readErr = err
+			}
+			break
+		}
+		rows <- row
+	}
+	close(rows)
+	wg.Wait()
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		return report.Rows[i].Row < report.Rows[j].Row
+	})
+	for _, res := range report.Rows {
+		switch {
+		case res.Error != nil:
+			report.Failed++
+		case res.Action == "update":
+			report.Updated++
+		default:
+			report.Added++
+		}
+	}
+
+	return report, readErr
+}
+
+// importRow adds or updates a single item, added items are recorded in `keys`
+func (list *List) importRow(row *listImportRow, fields []*transferField, keyField *transferField, keys *listImportKeys, options *ListImportOptions) *ListImportRowResult {
+	res := &ListImportRowResult{Row: row.num, Action: "add"}
+
+	type importColumn struct {
+		field *transferField
+		byID  bool
+		value interface{}
+	}
+	var columns []*importColumn
+	idColumns := map[string]bool{}
+	for column, value := range row.values {
+		name := column
+		if mapped, ok := options.Mapping[column]; ok {
+			name = mapped
+		}
+		field, byID := findTransferField(fields, name), false
+		if field == nil {
+			field, byID = findLookupIDField(fields, name)
+		}
+		if field == nil {
+			continue // columns not matching any field are skipped
+		}
+		if byID {
+			idColumns[field.InternalName] = true
+		}
+		columns = append(columns, &importColumn{field: field, byID: byID, value: value})
+	}
+
+	formValues := map[string]string{}
+	for _, column := range columns {
+		field := column.field
+		if keyField != nil && field.InternalName == keyField.InternalName && !column.byID {
+			res.Key = transferValueToString(column.value)
+		}
+		if field.ReadOnlyField || field.TypeAsString == "Counter" {
+			continue
+		}
+		if idColumns[field.InternalName] && !column.byID {
+			continue // `<Field>Id` column takes precedence over the lookup values column
+		}
+		var formValue string
+		var err error
+		if column.byID {
+			formValue, err = toTransferLookupFormValue(field, column.value, true)
+		} else {
+			formValue, err = toTransferFormValue(field, column.value, options)
+		}
+		if err != nil {
+			res.Error = fmt.Errorf("%s: %w", field.InternalName, err)
+			return res
+		}
+		formValues[field.InternalName] = formValue
+	}
+
+	if keyField != nil && res.Key != "" {
+		itemID := keys.lock(res.Key)
+		defer func() { keys.unlock(res.Key, res.ItemID) }()
+		if itemID == 0 {
+			return list.addRow(res, formValues, options)
+		}
+		res.Action = "update"
+		res.ItemID = itemID
+		_, res.Error = list.Items().GetByID(itemID).UpdateValidate(formValues, &ValidateUpdateOptions{
+			NewDocumentUpdate: options.NewDocumentUpdate,
+		})
+		return res
+	}
+
+	return list.addRow(res, formValues, options)
+}
+
+// addRow adds a new item with the row form values
+func (list *List) addRow(res *ListImportRowResult, formValues map[string]string, options *ListImportOptions) *ListImportRowResult {
+	data, err := list.Items().AddValidate(formValues, &ValidateAddOptions{
+		DecodedPath:       options.FolderPath,
+		NewDocumentUpdate: options.NewDocumentUpdate,
+	})
+	res.ItemID = data.ID()
+	res.Error = err
+	return res
+}
+
+// getItemsKeys gets a map of key field values to existing items IDs
+func (list *List) getItemsKeys(keyField *transferField) (map[string]int, error) {
+	selects, expands := transferFieldSelect(keyField)
+	items := list.Items().Select(strings.Join(append([]string{"Id"}, selects...), ",")).Top(5000)
+	if len(expands) > 0 {
+		items.Expand(strings.Join(expands, ","))
+	}
+	data, err := items.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	keys := map[string]int{}
+	for _, item := range data {
+		raw := map[string]interface{}{}
+		if err := json.Unmarshal(normalizeMultiLookups(NormalizeODataItem(item)), &raw); err != nil {
+			return nil, fmt.Errorf("unable to parse the response: %w", err)
+		}
+		key := transferValueToString(formatTransferValue(keyField, raw[keyField.InternalName], ""))
+		if key == "" {
+			continue
+		}
+		id, _ := strconv.Atoi(transferValueToString(raw["Id"]))
+		keys[key] = id
+	}
+	return keys, nil
+}
+
+// toTransferFormValue converts exported value representation to AddValidate/UpdateValidate form value string
+func toTransferFormValue(field *transferField, value interface{}, options *ListImportOptions) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	switch field.TypeAsString {
+	case "Lookup", "LookupMulti":
+		return toTransferLookupFormValue(field, value, false)
+	case "User", "UserMulti":
+		type userKey struct {
+			Key string `json:"Key"`
+		}
+		keys := []userKey{}
+		for _, login := range transferValues(value) {
+			keys = append(keys, userKey{Key: login})
+		}
+		if len(keys) == 0 {
+			return "", nil
+		}
+		res, _ := json.Marshal(keys)
+		return string(res), nil
+	case "TaxonomyFieldType", "TaxonomyFieldTypeMulti":
+		return strings.Join(transferValues(value), ";"), nil
+	case "MultiChoice":
+		return strings.Join(transferValues(value), multiValueSeparator), nil
+	case "Boolean":
+		switch strings.ToLower(transferValueToString(value)) {
+		case "true", "yes", "1":
+			return "1", nil
+		case "false", "no", "0":
+			return "0", nil
+		case "":
+			return "", nil
+		}
+		return "", fmt.Errorf("can't parse boolean value \"%v\"", value)
+	case "DateTime":
+		s := transferValueToString(value)
+		if options.DateLayout == "" {
+			return s, nil
+		}
+		d, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return s, nil
+		}
+		loc := options.TimeZone
+		if loc == nil {
+			loc = time.UTC
+		}
+		return d.In(loc).Format(options.DateLayout), nil
+	}
+	return transferValueToString(value), nil
+}
+
+// transferValues splits a value into a list of single values, strings are split by `;#`
+func transferValues(value interface{}) []string {
+	var values []string
+	switch v := value.(type) {
+	case []interface{}:
+		for _, val := range v {
+			if s := transferValueToString(val); s != "" {
+				values = append(values, s)
+			}
+		}
+	default:
+		for _, s := range strings.Split(transferValueToString(value), multiValueSeparator) {
+			if s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+// findLookupIDField resolves a `<Field>Id` column name to the lookup field it carries item IDs for
+func findLookupIDField(fields []*transferField, name string) (*transferField, bool) {
+	if !strings.HasSuffix(name, "Id") {
+		return nil, false
+	}
+	field := findTransferField(fields, strings.TrimSuffix(name, "Id"))
+	if field == nil || (field.TypeAsString != "Lookup" && field.TypeAsString != "LookupMulti") {
+		return nil, false
+	}
+	return field, true
+}
+
+// toTransferLookupFormValue converts a lookup value to AddValidate/UpdateValidate form value,
+// `<Field>Id` column values (byID) are item IDs, lookup column values are `ID;#Value` pairs
+func toTransferLookupFormValue(field *transferField, value interface{}, byID bool) (string, error) {
+	ids, err := transferLookupIDs(value, byID)
+	if err != nil {
+		return "", err
+	}
+	if field.TypeAsString == "Lookup" && len(ids) > 1 {
+		return "", fmt.Errorf("single lookup accepts one value, got %d", len(ids))
+	}
+	return strings.Join(ids, ";#;#"), nil
+}
+
+// transferLookupIDs gets lookup IDs from item IDs (`ID;#ID`) when byID is set, otherwise from exported `ID;#Value;#ID;#Value` pairs.
+// A value which is a number is ambiguous, it's never treated as an ID unless it comes from a `<Field>Id` column.
+func transferLookupIDs(value interface{}, byID bool) ([]string, error) {
+	var ids []string
+	if byID {
+		for _, id := range transferValues(value) {
+			if _, err := strconv.Atoi(id); err != nil {
+				return nil, fmt.Errorf("lookup ID column value should be an item ID, got \"%s\"", id)
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+
+	var pairs []string
+	if arr, ok := value.([]interface{}); ok {
+		for _, val := range arr {
+			pair := strings.Split(transferValueToString(val), multiValueSeparator)
+			if len(pair) != 2 {
+				return nil, fmt.Errorf("lookup value should be an `ID;#Value` pair, got \"%v\", use a `<Field>Id` column for item IDs", val)
+			}
+			pairs = append(pairs, pair...)
+		}
+	} else if s := transferValueToString(value); s != "" {
+		pairs = strings.Split(s, multiValueSeparator)
+	}
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("lookup value should be `ID;#Value` pairs, got \"%v\", use a `<Field>Id` column for item IDs", value)
+	}
+	for i := 0; i < len(pairs); i += 2 {
+		if _, err := strconv.Atoi(pairs[i]); err != nil {
+			return nil, fmt.Errorf("lookup value should start with an item ID, got \"%s\"", pairs[i])
+		}
+		ids = append(ids, pairs[i])
+	}
+	return ids, nil
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestListImport(t *testing.T) {
+
+	t.Run("toTransferFormValue", func(t *testing.T) {
+		options := &ListImportOptions{DateLayout: "1/2/2006 3:04 PM"}
+		cases := []struct {
+			field    *transferField
+			value    interface{}
+			expected string
+		}{
+			{&transferField{TypeAsString: "Lookup"}, "3;#Three", "3"},
+			{&transferField{TypeAsString: "Lookup"}, "3;#2020", "3"},
+			{&transferField{TypeAsString: "LookupMulti"}, "1;#A;#2;#B", "1;#;#2"},
+			{&transferField{TypeAsString: "LookupMulti"}, []interface{}{"1;#A", "2;#B"}, "1;#;#2"},
+			{&transferField{TypeAsString: "LookupMulti"}, "1;#42;#2;#7", "1;#;#2"},
+			{&transferField{TypeAsString: "UserMulti"}, "user1;#user2", `[{"Key":"user1"},{"Key":"user2"}]`},
+			{&transferField{TypeAsString: "TaxonomyFieldTypeMulti"}, "A|g1;#B|g2", "A|g1;B|g2"},
+			{&transferField{TypeAsString: "MultiChoice"}, []interface{}{"A", "B"}, "A;#B"},
+			{&transferField{TypeAsString: "Boolean"}, "Yes", "1"},
+			{&transferField{TypeAsString: "Boolean"}, false, "0"},
+			{&transferField{TypeAsString: "DateTime"}, "2020-01-02T08:00:00Z", "1/2/2020 8:00 AM"},
+			{&transferField{TypeAsString: "Number"}, 42.0, "42"},
+		}
+		for _, c := range cases {
+			res, err := toTransferFormValue(c.field, c.value, options)
+			if err != nil {
+				t.Error(err)
+			}
+			if res != c.expected {
+				t.Errorf("incorrect %s form value, expected \"%s\", got \"%s\"", c.field.TypeAsString, c.expected, res)
+			}
+		}
+		if _, err := toTransferFormValue(&transferField{TypeAsString: "Lookup"}, "Three", options); err == nil {
+			t.Error("failed to detect a lookup value without ID")
+		}
+		if _, err := toTransferFormValue(&transferField{TypeAsString: "LookupMulti"}, "1;#2;#3", options); err == nil {
+			t.Error("failed to detect item IDs passed in a lookup column")
+		}
+	})
+
+	t.Run("lookupIDColumns", func(t *testing.T) {
+		fields := []*transferField{
+			{InternalName: "Manager", TypeAsString: "Lookup"},
+			{InternalName: "Projects", TypeAsString: "LookupMulti"},
+			{InternalName: "Title", TypeAsString: "Text"},
+		}
+		if field, byID := findLookupIDField(fields, "ManagerId"); field == nil || !byID {
+			t.Error("failed to resolve lookup ID column")
+		}
+		if field, _ := findLookupIDField(fields, "TitleId"); field != nil {
+			t.Error("non lookup field resolved as lookup ID column")
+		}
+		cases := []struct {
+			field    *transferField
+			value    interface{}
+			expected string
+		}{
+			{fields[0], "42", "42"},
+			{fields[1], "1;#2", "1;#;#2"},
+			{fields[1], []interface{}{1.0, 2.0}, "1;#;#2"},
+		}
+		for _, c := range cases {
+			res, err := toTransferLookupFormValue(c.field, c.value, true)
+			if err != nil {
+				t.Error(err)
+			}
+			if res != c.expected {
+				t.Errorf("incorrect %s form value, expected \"%s\", got \"%s\"", c.field.InternalName, c.expected, res)
+			}
+		}
+		if _, err := toTransferLookupFormValue(fields[0], "1;#2", true); err == nil {
+			t.Error("failed to detect multiple values in a single lookup")
+		}
+		if _, err := toTransferLookupFormValue(fields[0], "Three", true); err == nil {
+			t.Error("failed to detect a non ID value in a lookup ID column")
+		}
+	})
+
+	t.Run("listImportKeys", func(t *testing.T) {
+		keys := &listImportKeys{ids: map[string]int{"Item 1": 1}, locks: map[string]*sync.Mutex{}}
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		added := 0
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				itemID := keys.lock("Item 2")
+				if itemID == 0 {
+					mu.Lock()
+					added++
+					mu.Unlock()
+					itemID = 2
+				}
+				keys.unlock("Item 2", itemID)
+			}()
+		}
+		wg.Wait()
+		if added != 1 {
+			t.Errorf("duplicate keys should have been added once, got %d", added)
+		}
+		if itemID := keys.lock("Item 1"); itemID != 1 {
+			t.Errorf("unexpected existing item ID %d", itemID)
+		}
+		keys.unlock("Item 1", 1)
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	newListTitle := strings.Replace(uuid.New().String(), "-", "", -1)
+	if _, err := web.Lists().Add(newListTitle, nil); err != nil {
+		t.Error(err)
+	}
+	list := web.Lists().GetByTitle(newListTitle)
+
+	t.Run("ImportCSV", func(t *testing.T) {
+		if envCode == "2013" {
+			t.Skip("is not supported with SP 2013")
+		}
+		csvData := "Title,Extra\nItem 1,skipped\nItem 2,skipped\n"
+		report, err := list.ImportCSV(strings.NewReader(csvData), &ListImportOptions{KeyField: "Title"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Added != 2 || report.Failed != 0 {
+			t.Errorf("unexpected import report: %+v", report)
+		}
+	})
+
+	t.Run("ImportJSONLUpsert", func(t *testing.T) {
+		if envCode == "2013" {
+			t.Skip("is not supported with SP 2013")
+		}
+		jsonlData := `{"Title":"Item 2"}` + "\n" + `{"Title":"Item 3"}` + "\n" + `{"Title":"Item 3"}` + "\n"
+		report, err := list.ImportJSONL(strings.NewReader(jsonlData), &ListImportOptions{KeyField: "Title"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Added != 1 || report.Updated != 2 {
+			t.Errorf("unexpected import report: %+v", report)
+		}
+	})
+
+	if err := list.Delete(); err != nil {
+		t.Error(err)
+	}
+
+}
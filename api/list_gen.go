@@ -1,4 +1,4 @@
-// Code generated by `ggen -ent List -conf -mods Select,Expand -helpers Normalized`; DO NOT EDIT.
+// Code generated by `ggen -ent List -conf -etag -mods Select,Expand -helpers Normalized`; DO NOT EDIT.
 
 package api
 
@@ -26,3 +26,18 @@ func (list *List) Expand(oDataExpand string) *List {
 func (listResp *ListResp) Normalized() []byte {
 	return NormalizeODataItem(*listResp)
 }
+
+/* Optimistic concurrency helpers */
+
+// IfMatch gets a copy of the entity with the expected ETag for Update and Delete requests,
+// the requests fail with *PreconditionFailedError when the entity was modified since the ETag was received
+func (list *List) IfMatch(etag string) *List {
+	scoped := *list
+	scoped.config = withIfMatch(list.config, etag)
+	return &scoped
+}
+
+// ETag gets entity ETag from the response metadata
+func (listResp *ListResp) ETag() string {
+	return getODataETag(*listResp)
+}
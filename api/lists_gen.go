@@ -55,3 +55,36 @@ func (listsResp *ListsResp) Normalized() []byte {
 	normalized, _ := NormalizeODataCollection(*listsResp)
 	return normalized
 }
+
+/* Pagination helpers */
+
+// ListsPager - Lists collection pages iterator
+type ListsPager struct {
+	*Pager
+}
+
+// Pager gets Lists collection pages iterator, the iteration follows OData next page links
+func (lists *Lists) Pager() *ListsPager {
+	return &ListsPager{NewPager(lists.client, lists.ToURL(), lists.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *ListsPager) Prefetch(enabled bool) *ListsPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *ListsPager) Page() ListsResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (listsResp *ListsResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*listsResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (listsResp *ListsResp) HasNextPage() bool {
+	return listsResp.NextPageURL() != ""
+}
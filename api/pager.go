@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+
+	"github.com/pnocera/gosip"
+)
+
+// Pager iterates through OData collection pages following `odata.nextLink` or `__next` links.
+// Typed pagers are available with `Pager()` method on collections, e.g. `sp.Web().Lists().Pager()`.
+//
+//	pager := sp.Web().Lists().Top(100).Pager()
+//	for pager.Next() {
+//		page := pager.Page()
+//		for _, list := range page.Data() {
+//			// process list
+//		}
+//	}
+//	if err := pager.Err(); err != nil {
+//		// handle error
+//	}
+//
+// Pager is context aware, iteration stops with the context error when RequestConfig.Context is done.
+type Pager struct {
+	load     pagerLoader
+	ctx      context.Context
+	cursor   string
+	started  bool
+	page     []byte
+	err      error
+	prefetch bool
+	pending  chan *pagerResult
+}
+
+// pagerLoader loads a page by its cursor and returns the page along with the next page cursor, empty when no more pages
+type pagerLoader func(cursor string) ([]byte, string, error)
+
+// pagerResult - page loading result
+type pagerResult struct {
+	page   []byte
+	cursor string
+	err    error
+}
+
+// NewPager - Pager struct constructor function, `endpoint` is a collection endpoint with OData modifiers
+func NewPager(client *gosip.SPClient, endpoint string, config *RequestConfig) *Pager {
+	return newPager(endpoint, config, func(pageURL string) ([]byte, string, error) {
+		data, err := NewHTTPClient(client).Get(pageURL, config)
+		if err != nil {
+			return nil, "", err
+		}
+		return data, getODataCollectionNextPageURL(data), nil
+	})
+}
+
+// newPager creates a pager with a custom page loader, `cursor` is the first page cursor
+func newPager(cursor string, config *RequestConfig, load pagerLoader) *Pager {
+	ctx := context.Background()
+	if config != nil && config.Context != nil {
+		ctx = config.Context
+	}
+	return &Pager{
+		load:   load,
+		ctx:    ctx,
+		cursor: cursor,
+	}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *Pager) Prefetch(enabled bool) *Pager {
+	pager.prefetch = enabled
+	return pager
+}
+
+// Next loads the next page, returns false when there are no more pages or an error occurred
+func (pager *Pager) Next() bool {
+	if pager.err != nil {
+		return false
+	}
+	if err := pager.ctx.Err(); err != nil {
+		pager.err = err
+		pager.page = nil
+		return false
+	}
+	if pager.started && pager.cursor == "" && pager.pending == nil {
+		pager.page = nil
+		return false
+	}
+	pager.started = true
+
+	var res *pagerResult
+	if pager.pending != nil {
+		select {
+		case res = <-pager.pending:
+		case <-pager.ctx.Done():
+			res = &pagerResult{err: pager.ctx.Err()}
+		}
+		pager.pending = nil
+	} else {
+		res = pager.fetch(pager.cursor)
+	}
+
+	if res.err != nil {
+		pager.err = res.err
+		pager.page = nil
+		return false
+	}
+	pager.page = res.page
+	pager.cursor = res.cursor
+
+	if pager.prefetch && pager.cursor != "" {
+		pending := make(chan *pagerResult, 1)
+		go func(cursor string) {
+			pending <- pager.fetch(cursor)
+		}(pager.cursor)
+		pager.pending = pending
+		pager.cursor = ""
+	}
+
+	return true
+}
+
+// Page gets current page raw response
+func (pager *Pager) Page() []byte {
+	return pager.page
+}
+
+// Err gets the error which stopped the iteration, nil when all pages were loaded
+func (pager *Pager) Err() error {
+	return pager.err
+}
+
+// fetch loads a page by cursor
+func (pager *Pager) fetch(cursor string) *pagerResult {
+	page, next, err := pager.load(cursor)
+	return &pagerResult{page: page, cursor: next, err: err}
+}
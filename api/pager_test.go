@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestPager(t *testing.T) {
+
+	pages := map[string]struct {
+		data []byte
+		next string
+	}{
+		"p1": {[]byte(`{"value":[{"Id":1}],"odata.nextLink":"p2"}`), "p2"},
+		"p2": {[]byte(`{"value":[{"Id":2}],"odata.nextLink":"p3"}`), "p3"},
+		"p3": {[]byte(`{"value":[{"Id":3}]}`), ""},
+	}
+	loader := func(cursor string) ([]byte, string, error) {
+		p, ok := pages[cursor]
+		if !ok {
+			return nil, "", fmt.Errorf("unknown page %s", cursor)
+		}
+		return p.data, p.next, nil
+	}
+
+	t.Run("Next", func(t *testing.T) {
+		for _, prefetch := range []bool{false, true} {
+			pager := newPager("p1", nil, loader).Prefetch(prefetch)
+			cnt := 0
+			for pager.Next() {
+				cnt++
+				items := ItemsResp(pager.Page())
+				if len(items.Data()) != 1 || items.Data()[0].Data().ID != cnt {
+					t.Errorf("incorrect page %d: %s", cnt, pager.Page())
+				}
+			}
+			if err := pager.Err(); err != nil {
+				t.Error(err)
+			}
+			if cnt != 3 {
+				t.Errorf("incorrect pages number, expected 3, got %d (prefetch: %t)", cnt, prefetch)
+			}
+			if pager.Next() {
+				t.Error("pager should stay completed")
+			}
+		}
+	})
+
+	t.Run("Err", func(t *testing.T) {
+		pager := newPager("p0", nil, loader)
+		if pager.Next() {
+			t.Error("should not load unknown page")
+		}
+		if pager.Err() == nil {
+			t.Error("error expected")
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		pager := newPager("p1", &RequestConfig{Context: ctx}, loader).Prefetch(true)
+		if !pager.Next() {
+			t.Fatal(pager.Err())
+		}
+		cancel()
+		if pager.Next() {
+			t.Error("should stop on context cancellation")
+		}
+		if pager.Err() != context.Canceled {
+			t.Errorf("context error expected, got %v", pager.Err())
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+
+	t.Run("Lists", func(t *testing.T) {
+		for _, conf := range []*RequestConfig{headers.verbose, headers.minimalmetadata, headers.nometadata} {
+			pager := web.Lists().Conf(conf).Select("Id").Top(1).Pager().Prefetch(true)
+			cnt := 0
+			for pager.Next() && cnt < 3 {
+				page := pager.Page()
+				if len(page.Data()) != 1 {
+					t.Error("incorrect page size")
+				}
+				cnt++
+			}
+			if err := pager.Err(); err != nil {
+				t.Error(err)
+			}
+			if cnt == 0 {
+				t.Error("no pages loaded")
+			}
+		}
+	})
+
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pnocera/gosip"
+)
+
+// PeoplePicker represents SharePoint People Picker API object struct, resolves principals by
+// login names, emails, display name fragments or group names
+// Always use NewPeoplePicker constructor instead of &PeoplePicker{}
+type PeoplePicker struct {
+	client   *gosip.SPClient
+	config   *RequestConfig
+	endpoint string
+}
+
+// PrincipalTypes - principal types enumerator, the values can be combined with `|`
+var PrincipalTypes = struct {
+	None             int
+	User             int
+	DistributionList int
+	SecurityGroup    int
+	SharePointGroup  int
+	All              int
+}{
+	None:             0,
+	User:             1,
+	DistributionList: 2,
+	SecurityGroup:    4,
+	SharePointGroup:  8,
+	All:              15,
+}
+
+// PrincipalSources - principal sources enumerator, the values can be combined with `|`
+var PrincipalSources = struct {
+	None               int
+	UserInfoList       int
+	Windows            int
+	MembershipProvider int
+	RoleProvider       int
+	All                int
+}{
+	None:               0,
+	UserInfoList:       1,
+	Windows:            2,
+	MembershipProvider: 4,
+	RoleProvider:       8,
+	All:                15,
+}
+
+// PeoplePickerOptions provides optional settings for people picker queries
+type PeoplePickerOptions struct {
+	PrincipalType       int  // principal types to search, see PrincipalTypes, All by default
+	PrincipalSource     int  // principal sources to search, see PrincipalSources, All by default
+	MaxResults          int  // maximum number of suggestions, 30 by default
+	AllowEmailAddresses bool // allow resolving arbitrary email addresses
+	SharePointGroupID   int  // restrict the search to the members of the SharePoint group
+}
+
+// PeoplePickerEntity resolved principal entity
+type PeoplePickerEntity struct {
+	Key                 string                  `json:"Key"` // login name for users and security groups, title for SharePoint groups
+	DisplayText         string                  `json:"DisplayText"`
+	Description         string                  `json:"Description"`
+	EntityType          string                  `json:"EntityType"` // "User", "SecGroup", "SPGroup", "FormsRole"
+	IsResolved          bool                    `json:"IsResolved"`
+	ProviderName        string                  `json:"ProviderName"`
+	ProviderDisplayName string                  `json:"ProviderDisplayName"`
+	EntityData          *PeoplePickerEntityData `json:"EntityData"`
+	MultipleMatches     []*PeoplePickerEntity   `json:"MultipleMatches"`
+}
+
+// PeoplePickerEntityData resolved principal entity data
+type PeoplePickerEntityData struct {
+	AccountName   string `json:"AccountName"`
+	Department    string `json:"Department"`
+	Email         string `json:"Email"`
+	MobilePhone   string `json:"MobilePhone"`
+	ObjectID      string `json:"ObjectId"`
+	PrincipalType string `json:"PrincipalType"`
+	SPGroupID     string `json:"SPGroupID"`
+	Title         string `json:"Title"`
+}
+
+// NewPeoplePicker - PeoplePicker struct constructor function
+func NewPeoplePicker(client *gosip.SPClient, endpoint string, config *RequestConfig) *PeoplePicker {
+	return &PeoplePicker{
+		client:   client,
+		endpoint: endpoint,
+		config:   config,
+	}
+}
+
+// PeoplePicker gets People Picker API instance object scoped to this web
+func (web *Web) PeoplePicker() *PeoplePicker {
+	return NewPeoplePicker(
+		web.client,
+		getPriorEndpoint(web.endpoint, "/_api")+"/_api/SP.UI.ApplicationPages.ClientPeoplePickerWebServiceInterface",
+		web.config,
+	)
+}
+
+// Search searches principals matching the query, e.g. a display name fragment or an email
+func (picker *PeoplePicker) Search(query string, options *PeoplePickerOptions) ([]*PeoplePickerEntity, error) {
+	data, err := picker.query("clientPeoplePickerSearchUser", query, options)
+	if err != nil {
+		return nil, err
+	}
+	var entities []*PeoplePickerEntity
+	if err := json.Unmarshal(data, &entities); err != nil {
+		return nil, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	return entities, nil
+}
+
+// Resolve resolves a principal by the query, check IsResolved and MultipleMatches of the result for ambiguous queries
+func (picker *PeoplePicker) Resolve(query string, options *PeoplePickerOptions) (*PeoplePickerEntity, error) {
+	data, err := picker.query("clientPeoplePickerResolveUser", query, options)
+	if err != nil {
+		return nil, err
+	}
+	entity := &PeoplePickerEntity{}
+	if err := json.Unmarshal(data, &entity); err != nil {
+		return nil, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	return entity, nil
+}
+
+// EnsurePrincipal gets the site principal ID of the entity to use in Roles.AddAssigment,
+// users and security groups are ensured in the web
+func (picker *PeoplePicker) EnsurePrincipal(entity *PeoplePickerEntity) (int, error) {
+	if entity.EntityType == "SPGroup" && entity.EntityData != nil && entity.EntityData.SPGroupID != "" {
+		return strconv.Atoi(entity.EntityData.SPGroupID)
+	}
+	web := NewWeb(picker.client, getPriorEndpoint(picker.endpoint, "/_api")+"/_api/Web", picker.config)
+	user, err := web.EnsureUser(entity.Key)
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+// query calls people picker method and gets the entities JSON
+func (picker *PeoplePicker) query(method string, query string, options *PeoplePickerOptions) ([]byte, error) {
+	if options == nil {
+		options = &PeoplePickerOptions{}
+	}
+	principalType := options.PrincipalType
+	if principalType == 0 {
+		principalType = PrincipalTypes.All
+	}
+	principalSource := options.PrincipalSource
+	if principalSource == 0 {
+		principalSource = PrincipalSources.All
+	}
+	maxResults := options.MaxResults
+	if maxResults == 0 {
+		maxResults = 30
+	}
+	params := map[string]interface{}{
+		"__metadata":               map[string]string{"type": "SP.UI.ApplicationPages.ClientPeoplePickerQueryParameters"},
+		"AllowEmailAddresses":      options.AllowEmailAddresses,
+		"AllowMultipleEntities":    false,
+		"AllUrlZones":              false,
+		"MaximumEntitySuggestions": maxResults,
+		"PrincipalSource":          principalSource,
+		"PrincipalType":            principalType,
+		"QueryString":              query,
+	}
+	if options.SharePointGroupID != 0 {
+		params["SharePointGroupID"] = options.SharePointGroupID
+	}
+	body, _ := json.Marshal(map[string]interface{}{"queryParams": params})
+
+	client := NewHTTPClient(picker.client)
+	data, err := client.Post(fmt.Sprintf("%s.%s", picker.endpoint, method), bytes.NewBuffer(body), picker.config)
+	if err != nil {
+		return nil, err
+	}
+	return parsePeoplePickerResp(data, method)
+}
+
+// parsePeoplePickerResp gets entities JSON from the response, people picker methods return
+// the entities serialized to a string wrapped into the method name (verbose) or `value` property
+func parsePeoplePickerResp(data []byte, method string) ([]byte, error) {
+	res := map[string]json.RawMessage{}
+	if err := json.Unmarshal(NormalizeODataItem(data), &res); err != nil {
+		return nil, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	for key, value := range res {
+		if strings.EqualFold(key, method) || key == "value" {
+			var entities string
+			if err := json.Unmarshal(value, &entities); err != nil {
+				return nil, fmt.Errorf("unable to parse the response: %w", err)
+			}
+			return []byte(entities), nil
+		}
+	}
+	return nil, fmt.Errorf("no %s result in the response", method)
+}
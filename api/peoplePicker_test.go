@@ -0,0 +1,85 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestPeoplePicker(t *testing.T) {
+
+	t.Run("ParsePeoplePickerResp", func(t *testing.T) {
+		verbose := []byte(`{"d":{"ClientPeoplePickerSearchUser":"[{\"Key\":\"i:0#.f|membership|user@contoso.com\",\"EntityType\":\"User\",\"IsResolved\":true,\"EntityData\":{\"Email\":\"user@contoso.com\"}}]"}}`)
+		nometadata := []byte(`{"value":"[{\"Key\":\"i:0#.f|membership|user@contoso.com\",\"EntityType\":\"User\",\"IsResolved\":true,\"EntityData\":{\"Email\":\"user@contoso.com\"}}]"}`)
+		for _, resp := range [][]byte{verbose, nometadata} {
+			data, err := parsePeoplePickerResp(resp, "clientPeoplePickerSearchUser")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != `[{"Key":"i:0#.f|membership|user@contoso.com","EntityType":"User","IsResolved":true,"EntityData":{"Email":"user@contoso.com"}}]` {
+				t.Errorf("unexpected entities: %s", data)
+			}
+		}
+		if _, err := parsePeoplePickerResp([]byte(`{"d":{}}`), "clientPeoplePickerSearchUser"); err == nil {
+			t.Error("missing result should have failed")
+		}
+	})
+
+	checkClient(t)
+
+	sp := NewSP(spClient)
+	user, err := sp.Web().CurrentUser().Select("Title,Email,LoginName").Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	picker := sp.Web().PeoplePicker()
+
+	t.Run("Search", func(t *testing.T) {
+		entities, err := picker.Search(user.Data().Title, &PeoplePickerOptions{
+			PrincipalType: PrincipalTypes.User,
+			MaxResults:    5,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entities) == 0 {
+			t.Errorf("can't find current user by title %s", user.Data().Title)
+		}
+	})
+
+	t.Run("Resolve", func(t *testing.T) {
+		entity, err := picker.Resolve(user.Data().LoginName, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !entity.IsResolved {
+			t.Errorf("can't resolve %s", user.Data().LoginName)
+		}
+		id, err := picker.EnsurePrincipal(entity)
+		if err != nil {
+			t.Error(err)
+		}
+		if id == 0 {
+			t.Error("can't ensure resolved principal")
+		}
+	})
+
+	t.Run("ResolveGroup", func(t *testing.T) {
+		group, err := sp.Web().AssociatedGroups().Members().Select("Id,Title").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entity, err := sp.PeoplePicker().Resolve(group.Data().Title, &PeoplePickerOptions{
+			PrincipalType:   PrincipalTypes.SharePointGroup,
+			PrincipalSource: PrincipalSources.UserInfoList,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, err := picker.EnsurePrincipal(entity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != group.Data().ID {
+			t.Errorf("expected group ID %d, got %d", group.Data().ID, id)
+		}
+	})
+}
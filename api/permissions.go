@@ -1,5 +1,15 @@
 package api
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/pnocera/gosip"
+)
+
 // BasePermissions - Low/High pair of base permissions
 type BasePermissions struct {
 	High int64 `json:"High,string"`
@@ -9,8 +19,10 @@ type BasePermissions struct {
 // RoleAssigment role assignments model
 type RoleAssigment struct {
 	Member *struct {
+		ID            int `json:"Id"`
 		LoginName     string
 		PrincipalType int
+		Title         string
 	}
 	RoleDefinitionBindings []*RoleDefInfo
 }
@@ -194,16 +206,115 @@ func HasPermissions(basePermissions BasePermissions, permissionsKind int64) bool
 	high := uint64(basePermissions.High)
 
 	if permissionsKind == PermissionKind.FullMask {
-		return (high&32767) == 32767 && low == 65535
+		return (high&0x7FFFFFFF) == 0x7FFFFFFF && (low&0xFFFFFFFF) == 0xFFFFFFFF
 	}
 
-	if perm >= 0 && perm < 32 {
+	if perm < 32 {
 		num = num << perm
 		return 0 != (low & num)
-	} else if perm >= 32 && perm < 64 {
-		num = num<<perm - 32
+	} else if perm < 64 {
+		num = num << (perm - 32)
 		return 0 != (high & num)
 	}
 
 	return false
 }
+
+// EncodePermissions builds base permissions mask from PermissionKind values, the inverse of HasPermissions,
+// e.g. EncodePermissions(PermissionKind.ViewListItems, PermissionKind.AddListItems, PermissionKind.EditListItems)
+func EncodePermissions(permissionsKinds ...int64) BasePermissions {
+	var low, high uint64
+	for _, kind := range permissionsKinds {
+		if kind == PermissionKind.EmptyMask {
+			continue
+		}
+		if kind == PermissionKind.FullMask {
+			low |= 0xFFFFFFFF
+			high |= 0x7FFFFFFF
+			continue
+		}
+		perm := uint64(kind - 1)
+		if perm < 32 {
+			low |= uint64(1) << perm
+		} else if perm < 64 {
+			high |= uint64(1) << (perm - 32)
+		}
+	}
+	return BasePermissions{High: int64(high), Low: int64(low)}
+}
+
+// DecodePermissions gets names of PermissionKind flags included in base permissions,
+// FullMask is the only name returned for full control permissions
+func DecodePermissions(basePermissions BasePermissions) []string {
+	if HasPermissions(basePermissions, PermissionKind.FullMask) {
+		return []string{"FullMask"}
+	}
+	var kinds []string
+	v := reflect.ValueOf(PermissionKind)
+	for i := 0; i < v.NumField(); i++ {
+		kind := v.Field(i).Int()
+		if kind == PermissionKind.EmptyMask || kind == PermissionKind.FullMask {
+			continue
+		}
+		if HasPermissions(basePermissions, kind) {
+			kinds = append(kinds, v.Type().Field(i).Name)
+		}
+	}
+	return kinds
+}
+
+// SecurableObject is a securable object with effective permissions API, e.g. Web, List, Item, Folder or File
+type SecurableObject interface {
+	GetUserEffectivePermissions(loginName string) (*BasePermissions, error)
+	EffectiveBasePermissions() (*BasePermissions, error)
+}
+
+// CanUser checks if the user has permissions kind on the securable object
+// permissionsKind is represented with in64 value (use PermissionKind struct helper as enumerator)
+func CanUser(object SecurableObject, loginName string, permissionsKind int64) (bool, error) {
+	permissions, err := object.GetUserEffectivePermissions(loginName)
+	if err != nil {
+		return false, err
+	}
+	return HasPermissions(*permissions, permissionsKind), nil
+}
+
+// getUserEffectivePermissions gets user effective permissions on a securable object endpoint
+func getUserEffectivePermissions(client *gosip.SPClient, endpoint string, loginName string, config *RequestConfig) (*BasePermissions, error) {
+	endpoint = fmt.Sprintf(
+		"%s/GetUserEffectivePermissions(@user)?@user='%s'",
+		endpoint,
+		url.QueryEscape(strings.Replace(loginName, "'", "''", -1)),
+	)
+	data, err := NewHTTPClient(client).Get(endpoint, config)
+	if err != nil {
+		return nil, err
+	}
+	return parseBasePermissions(data, "GetUserEffectivePermissions")
+}
+
+// getEffectiveBasePermissions gets current user effective permissions on a securable object endpoint
+func getEffectiveBasePermissions(client *gosip.SPClient, endpoint string, config *RequestConfig) (*BasePermissions, error) {
+	data, err := NewHTTPClient(client).Get(fmt.Sprintf("%s/EffectiveBasePermissions", endpoint), config)
+	if err != nil {
+		return nil, err
+	}
+	return parseBasePermissions(data, "EffectiveBasePermissions")
+}
+
+// parseBasePermissions parses base permissions response, verbose mode wraps the value with the property name
+func parseBasePermissions(data []byte, prop string) (*BasePermissions, error) {
+	data = NormalizeODataItem(data)
+	res := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	if wrapped, ok := res[prop]; ok {
+		data = wrapped
+	}
+	permissions := &BasePermissions{}
+	if err := json.Unmarshal(data, permissions); err != nil {
+		return nil, fmt.Errorf("unable to parse the response: %w", err)
+	}
+	return permissions, nil
+}
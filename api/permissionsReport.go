@@ -0,0 +1,486 @@
+package api
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pnocera/gosip"
+)
+
+// PermissionsReportFormats - available permissions report formats
+var PermissionsReportFormats = struct {
+	JSON string // JSON lines, a record per line
+	CSV  string
+}{
+	JSON: "json",
+	CSV:  "csv",
+}
+
+// PermissionsReportOptions provides optional settings for PermissionsReport method
+type PermissionsReportOptions struct {
+	Concurrency      int    // number of objects scanned in parallel, default is 5
+	IncludeItems     bool   // scan list items and documents, is expensive for large lists
+	IncludeHidden    bool   // scan hidden lists
+	IncludeInherited bool   // report objects inheriting permissions with the parent assignments marked as inherited
+	ExpandGroups     bool   // report members of SharePoint groups
+	StateFile        string // crawl state file path, webs and lists completed in a previous run with the same file are skipped
+}
+
+// PermissionsRecord describes a permissions report record, a record per principal and securable object
+type PermissionsRecord struct {
+	ObjectURL       string   `json:"objectUrl"`       // server relative URL of the object
+	ObjectType      string   `json:"objectType"`      // web, list or item
+	Principal       string   `json:"principal"`       // principal login name
+	PrincipalTitle  string   `json:"principalTitle"`  // principal display name
+	PrincipalType   int      `json:"principalType"`   // 1 - user, 4 - security group, 8 - SharePoint group
+	Group           string   `json:"group,omitempty"` // SharePoint group granting the permissions to its member, when groups are expanded
+	RoleDefinitions []string `json:"roleDefinitions"` // role definitions names
+	Inherited       bool     `json:"inherited"`       // the object inherits permissions from its parent
+}
+
+// PermissionsReport crawls this web, its subwebs, lists and optionally items calling `emit` for each role assignment
+// of the objects with unique permissions. `emit` calls are serialized. The crawl stops with the first request or `emit` error.
+// When StateFile option is provided, the crawl can be resumed after a failure, records of the objects which were
+// in progress when the crawl stopped are emitted again.
+func (web *Web) PermissionsReport(options *PermissionsReportOptions, emit func(record *PermissionsRecord) error) error {
+	opts := PermissionsReportOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 5
+	}
+
+	crawler := &permissionsCrawler{
+		client:    web.client,
+		config:    web.config,
+		options:   &opts,
+		emit:      emit,
+		sem:       make(chan struct{}, opts.Concurrency),
+		completed: map[string]bool{},
+		groups:    map[int][]*UserInfo{},
+	}
+	if err := crawler.openState(); err != nil {
+		return err
+	}
+	defer crawler.closeState()
+
+	crawler.spawn(func() error {
+		return crawler.crawlWeb(NewWeb(web.client, web.endpoint, web.config), nil, true)
+	})
+	crawler.wg.Wait()
+	return crawler.failed()
+}
+
+// permissionsCrawler walks securable objects with limited concurrency
+type permissionsCrawler struct {
+	client  *gosip.SPClient
+	config  *RequestConfig
+	options *PermissionsReportOptions
+	emit    func(record *PermissionsRecord) error
+
+	sem       chan struct{}
+	wg        sync.WaitGroup
+	mu        sync.Mutex // guards emit calls, err, completed and state
+	err       error
+	completed map[string]bool
+	state     *os.File
+	groupsMu  sync.Mutex
+	groups    map[int][]*UserInfo // SharePoint groups members cache
+}
+
+// spawn runs the task in background limiting concurrency
+func (c *permissionsCrawler) spawn(task func() error) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.sem <- struct{}{}
+		defer func() { <-c.sem }()
+		if c.failed() != nil {
+			return
+		}
+		if err := task(); err != nil {
+			c.fail(err)
+		}
+	}()
+}
+
+// crawlWeb reports the web and schedules its lists and subwebs
+func (c *permissionsCrawler) crawlWeb(web *Web, parent []*RoleAssigment, isRoot bool) error {
+	data, err := NewWeb(c.client, web.endpoint, c.config).Select("ServerRelativeUrl,Url").Get()
+	if err != nil {
+		return err
+	}
+	info := &struct {
+		ServerRelativeURL string `json:"ServerRelativeUrl"`
+		URL               string `json:"Url"`
+	}{}
+	if err := json.Unmarshal(NormalizeODataItem(data), &info); err != nil {
+		return fmt.Errorf("unable to parse the response: %w", err)
+	}
+	unique, err := web.Roles().HasUniqueAssignments()
+	if err != nil {
+		return err
+	}
+
+	assigments := parent
+	if unique || isRoot {
+		if assigments, err = web.Roles().GetAssigments(); err != nil {
+			return err
+		}
+	}
+	key := "web:" + info.ServerRelativeURL
+	if !c.isCompleted(key) {
+		if unique || isRoot || c.options.IncludeInherited {
+			if err := c.report(web, info.ServerRelativeURL, "web", assigments, !unique); err != nil {
+				return err
+			}
+		}
+		if err := c.complete(key); err != nil {
+			return err
+		}
+	}
+
+	lists := web.Lists().Select("Id,Hidden,HasUniqueRoleAssignments,RootFolder/ServerRelativeUrl").Expand("RootFolder").Pager()
+	for lists.Next() {
+		collection, _ := NormalizeODataCollection(lists.Page())
+		var page []*permissionsListInfo
+		if err := json.Unmarshal(collection, &page); err != nil {
+			return fmt.Errorf("unable to parse the response: %w", err)
+		}
+		for _, list := range page {
+			list := list
+			if list.Hidden && !c.options.IncludeHidden {
+				continue
+			}
+			c.spawn(func() error {
+				return c.crawlList(web, list, assigments)
+			})
+		}
+	}
+	if err := lists.Err(); err != nil {
+		return err
+	}
+
+	webs := web.Webs().Select("Url").Pager()
+	for webs.Next() {
+		collection, _ := NormalizeODataCollection(webs.Page())
+		var page []*struct {
+			URL string `json:"Url"`
+		}
+		if err := json.Unmarshal(collection, &page); err != nil {
+			return fmt.Errorf("unable to parse the response: %w", err)
+		}
+		for _, sub := range page {
+			subWeb := NewWeb(c.client, sub.URL+"/_api/Web", c.config)
+			c.spawn(func() error {
+				return c.crawlWeb(subWeb, assigments, false)
+			})
+		}
+	}
+	return webs.Err()
+}
+
+// permissionsListInfo - list properties required for the crawl
+type permissionsListInfo struct {
+	ID                       string `json:"Id"`
+	Hidden                   bool   `json:"Hidden"`
+	HasUniqueRoleAssignments bool   `json:"HasUniqueRoleAssignments"`
+	RootFolder               struct {
+		ServerRelativeURL string `json:"ServerRelativeUrl"`
+	} `json:"RootFolder"`
+}
+
+// crawlList reports the list and its items
+func (c *permissionsCrawler) crawlList(web *Web, info *permissionsListInfo, parent []*RoleAssigment) error {
+	key := "list:" + info.RootFolder.ServerRelativeURL
+	if c.isCompleted(key) {
+		return nil
+	}
+	list := web.Lists().GetByID(info.ID)
+
+	assigments := parent
+	if info.HasUniqueRoleAssignments {
+		var err error
+		if assigments, err = list.Roles().GetAssigments(); err != nil {
+			return err
+		}
+	}
+	if info.HasUniqueRoleAssignments || c.options.IncludeInherited {
+		if err := c.report(web, info.RootFolder.ServerRelativeURL, "list", assigments, !info.HasUniqueRoleAssignments); err != nil {
+			return err
+		}
+	}
+
+	if c.options.IncludeItems {
+		if err := c.crawlItems(web, list, assigments); err != nil {
+			return err
+		}
+	}
+	return c.complete(key)
+}
+
+// crawlItems reports list items with unique permissions, inherited permissions are resolved
+// from the nearest parent folder with unique permissions
+func (c *permissionsCrawler) crawlItems(web *Web, list *List, listAssigments []*RoleAssigment) error {
+	type itemInfo struct {
+		ID                       int    `json:"Id"`
+		FileRef                  string `json:"FileRef"`
+		HasUniqueRoleAssignments bool   `json:"HasUniqueRoleAssignments"`
+	}
+	var items []*itemInfo
+	pager := list.Items().Select("Id,FileRef,HasUniqueRoleAssignments").Top(5000).Pager()
+	for pager.Next() {
+		collection, _ := NormalizeODataCollection(pager.Page())
+		var page []*itemInfo
+		if err := json.Unmarshal(collection, &page); err != nil {
+			return fmt.Errorf("unable to parse the response: %w", err)
+		}
+		items = append(items, page...)
+	}
+	if err := pager.Err(); err != nil {
+		return err
+	}
+
+	unique := map[string][]*RoleAssigment{}
+	for _, item := range items {
+		if !item.HasUniqueRoleAssignments {
+			continue
+		}
+		assigments, err := list.Items().GetByID(item.ID).Roles().GetAssigments()
+		if err != nil {
+			return err
+		}
+		unique[item.FileRef] = assigments
+		if err := c.report(web, item.FileRef, "item", assigments, false); err != nil {
+			return err
+		}
+	}
+
+	if !c.options.IncludeInherited {
+		return nil
+	}
+	for _, item := range items {
+		if item.HasUniqueRoleAssignments {
+			continue
+		}
+		assigments := listAssigments
+		for dir := path.Dir(item.FileRef); dir != "/" && dir != "."; dir = path.Dir(dir) {
+			if a, ok := unique[dir]; ok {
+				assigments = a
+				break
+			}
+		}
+		if err := c.report(web, item.FileRef, "item", assigments, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// report emits records of the object role assigments
+func (c *permissionsCrawler) report(web *Web, objectURL string, objectType string, assigments []*RoleAssigment, inherited bool) error {
+	var records []*PermissionsRecord
+	for _, a := range assigments {
+		if a.Member == nil {
+			continue
+		}
+		var roles []string
+		for _, r := range a.RoleDefinitionBindings {
+			roles = append(roles, r.Name)
+		}
+		records = append(records, &PermissionsRecord{
+			ObjectURL:       objectURL,
+			ObjectType:      objectType,
+			Principal:       a.Member.LoginName,
+			PrincipalTitle:  a.Member.Title,
+			PrincipalType:   a.Member.PrincipalType,
+			RoleDefinitions: roles,
+			Inherited:       inherited,
+		})
+		if a.Member.PrincipalType == 8 && c.options.ExpandGroups {
+			members, err := c.groupMembers(web, a.Member.ID)
+			if err != nil {
+				return err
+			}
+			for _, m := range members {
+				records = append(records, &PermissionsRecord{
+					ObjectURL:       objectURL,
+					ObjectType:      objectType,
+					Principal:       m.LoginName,
+					PrincipalTitle:  m.Title,
+					PrincipalType:   m.PrincipalType,
+					Group:           a.Member.Title,
+					RoleDefinitions: roles,
+					Inherited:       inherited,
+				})
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, record := range records {
+		if err := c.emit(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupMembers gets SharePoint group members, groups are shared across the site collection and are cached
+func (c *permissionsCrawler) groupMembers(web *Web, groupID int) ([]*UserInfo, error) {
+	c.groupsMu.Lock()
+	defer c.groupsMu.Unlock()
+	if members, ok := c.groups[groupID]; ok {
+		return members, nil
+	}
+	data, err := web.SiteGroups().GetByID(groupID).Users().Select("Id,LoginName,Title,PrincipalType").Get()
+	if err != nil {
+		return nil, err
+	}
+	var members []*UserInfo
+	for _, u := range data.Data() {
+		members = append(members, u.Data())
+	}
+	c.groups[groupID] = members
+	return members, nil
+}
+
+// openState reads completed scopes from the state file and opens it for appending
+func (c *permissionsCrawler) openState() error {
+	if c.options.StateFile == "" {
+		return nil
+	}
+	if f, err := os.Open(c.options.StateFile); err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if key := strings.TrimSpace(scanner.Text()); key != "" {
+				c.completed[key] = true
+			}
+		}
+		shut(f)
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("unable to read crawl state: %w", err)
+		}
+	}
+	f, err := os.OpenFile(c.options.StateFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open crawl state: %w", err)
+	}
+	c.state = f
+	return nil
+}
+
+// closeState closes the state file
+func (c *permissionsCrawler) closeState() {
+	if c.state != nil {
+		shut(c.state)
+	}
+}
+
+// isCompleted checks if the scope was completed in a previous run
+func (c *permissionsCrawler) isCompleted(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.completed[key]
+}
+
+// complete stores completed scope in the state file
+func (c *permissionsCrawler) complete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.completed[key] = true
+	if c.state == nil {
+		return nil
+	}
+	if _, err := c.state.WriteString(key + "\n"); err != nil {
+		return fmt.Errorf("unable to save crawl state: %w", err)
+	}
+	return nil
+}
+
+// fail keeps the first crawl error
+func (c *permissionsCrawler) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+// failed gets the first crawl error
+func (c *permissionsCrawler) failed() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// PermissionsReportWriter writes permissions records as JSON lines or CSV rows
+// Always use NewPermissionsReportWriter constructor instead of &PermissionsReportWriter{}
+type PermissionsReportWriter struct {
+	format string
+	w      io.Writer
+	csv    *csv.Writer
+	header bool
+}
+
+// NewPermissionsReportWriter - PermissionsReportWriter struct constructor function,
+// `format` is one of PermissionsReportFormats, `writeHeader` adds CSV header row, should be false when appending to a resumed report
+func NewPermissionsReportWriter(w io.Writer, format string, writeHeader bool) *PermissionsReportWriter {
+	rw := &PermissionsReportWriter{format: format, w: w, header: writeHeader}
+	if format == PermissionsReportFormats.CSV {
+		rw.csv = csv.NewWriter(w)
+	}
+	return rw
+}
+
+// Write writes a record, can be used as PermissionsReport `emit` callback
+func (rw *PermissionsReportWriter) Write(record *PermissionsRecord) error {
+	switch rw.format {
+	case PermissionsReportFormats.JSON:
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		_, err = rw.w.Write(append(data, '\n'))
+		return err
+	case PermissionsReportFormats.CSV:
+		if rw.header {
+			rw.header = false
+			if err := rw.csv.Write([]string{
+				"ObjectURL", "ObjectType", "Principal", "PrincipalTitle", "PrincipalType", "Group", "RoleDefinitions", "Inherited",
+			}); err != nil {
+				return err
+			}
+		}
+		return rw.csv.Write([]string{
+			record.ObjectURL,
+			record.ObjectType,
+			record.Principal,
+			record.PrincipalTitle,
+			strconv.Itoa(record.PrincipalType),
+			record.Group,
+			strings.Join(record.RoleDefinitions, "; "),
+			strconv.FormatBool(record.Inherited),
+		})
+	}
+	return fmt.Errorf("unknown report format %s", rw.format)
+}
+
+// Flush flushes buffered CSV rows
+func (rw *PermissionsReportWriter) Flush() error {
+	if rw.csv != nil {
+		rw.csv.Flush()
+		return rw.csv.Error()
+	}
+	return nil
+}
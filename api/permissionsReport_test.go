@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPermissionsReport(t *testing.T) {
+
+	record := &PermissionsRecord{
+		ObjectURL:       "/sites/site/Shared Documents",
+		ObjectType:      "list",
+		Principal:       "i:0#.f|membership|john@contoso.com",
+		PrincipalTitle:  "John, Doe",
+		PrincipalType:   1,
+		RoleDefinitions: []string{"Read", "Contribute"},
+	}
+
+	t.Run("WriterJSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		rw := NewPermissionsReportWriter(&buf, PermissionsReportFormats.JSON, true)
+		if err := rw.Write(record); err != nil {
+			t.Fatal(err)
+		}
+		if err := rw.Write(record); err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("expected 2 JSON lines, got %d", len(lines))
+		}
+		r := &PermissionsRecord{}
+		if err := json.Unmarshal([]byte(lines[0]), r); err != nil {
+			t.Fatal(err)
+		}
+		if r.Principal != record.Principal || len(r.RoleDefinitions) != 2 {
+			t.Errorf("incorrect record: %+v", r)
+		}
+	})
+
+	t.Run("WriterCSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		rw := NewPermissionsReportWriter(&buf, PermissionsReportFormats.CSV, true)
+		if err := rw.Write(record); err != nil {
+			t.Fatal(err)
+		}
+		if err := rw.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		expected := "ObjectURL,ObjectType,Principal,PrincipalTitle,PrincipalType,Group,RoleDefinitions,Inherited\n" +
+			"/sites/site/Shared Documents,list,i:0#.f|membership|john@contoso.com,\"John, Doe\",1,,Read; Contribute,false\n"
+		if buf.String() != expected {
+			t.Errorf("incorrect CSV:\n%s", buf.String())
+		}
+	})
+
+	t.Run("State", func(t *testing.T) {
+		stateFile := filepath.Join(t.TempDir(), "crawl.state")
+		c := &permissionsCrawler{options: &PermissionsReportOptions{StateFile: stateFile}, completed: map[string]bool{}}
+		if err := c.openState(); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.complete("list:/sites/site/Shared Documents"); err != nil {
+			t.Fatal(err)
+		}
+		c.closeState()
+
+		resumed := &permissionsCrawler{options: &PermissionsReportOptions{StateFile: stateFile}, completed: map[string]bool{}}
+		if err := resumed.openState(); err != nil {
+			t.Fatal(err)
+		}
+		defer resumed.closeState()
+		if !resumed.isCompleted("list:/sites/site/Shared Documents") || resumed.isCompleted("web:/sites/site") {
+			t.Error("incorrect resumed state")
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+
+	t.Run("Crawl", func(t *testing.T) {
+		var records []*PermissionsRecord
+		err := web.PermissionsReport(&PermissionsReportOptions{ExpandGroups: true}, func(record *PermissionsRecord) error {
+			records = append(records, record)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) == 0 {
+			t.Fatal("no records were reported")
+		}
+		if records[0].ObjectType != "web" {
+			t.Errorf("root web records are expected first, got %s", records[0].ObjectType)
+		}
+	})
+
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -43,6 +44,115 @@ func TestPermissions(t *testing.T) {
 			t.Error("should not have permissions")
 		}
 
+		fullControl := BasePermissions{High: 2147483647, Low: 4294967295}
+		if has := HasPermissions(fullControl, PermissionKind.FullMask); !has {
+			t.Error("should have full permissions")
+		}
+
+	})
+
+	t.Run("HighWord", func(t *testing.T) {
+
+		// Edit role high word: UseClientIntegration, UseRemoteAPIs, CreateAlerts, EditMyUserInfo
+		for _, kind := range []int64{
+			PermissionKind.UseClientIntegration,
+			PermissionKind.UseRemoteAPIs,
+			PermissionKind.CreateAlerts,
+			PermissionKind.EditMyUserInfo,
+		} {
+			if has := HasPermissions(editPermissions, kind); !has {
+				t.Errorf("should have %d permissions", kind)
+			}
+		}
+		for _, kind := range []int64{PermissionKind.ManageAlerts, PermissionKind.EnumeratePermissions} {
+			if has := HasPermissions(editPermissions, kind); has {
+				t.Errorf("should not have %d permissions", kind)
+			}
+		}
+
+	})
+
+	t.Run("DecodePermissions", func(t *testing.T) {
+
+		readPermissions := BasePermissions{High: 176, Low: 138612833}
+		expected := []string{
+			"ViewListItems", "OpenItems", "ViewVersions", "ViewFormPages", "Open", "ViewPages", "CreateSSCSite",
+			"BrowseUserInfo", "UseClientIntegration", "UseRemoteAPIs", "CreateAlerts",
+		}
+		if kinds := DecodePermissions(readPermissions); !reflect.DeepEqual(kinds, expected) {
+			t.Errorf("incorrect decoded permissions: %v", kinds)
+		}
+		if kinds := DecodePermissions(BasePermissions{High: 2147483647, Low: 4294967295}); !reflect.DeepEqual(kinds, []string{"FullMask"}) {
+			t.Errorf("incorrect decoded full permissions: %v", kinds)
+		}
+
+	})
+
+	t.Run("EncodePermissions", func(t *testing.T) {
+
+		readPermissions := BasePermissions{High: 176, Low: 138612833}
+		kinds := []int64{
+			PermissionKind.ViewListItems, PermissionKind.OpenItems, PermissionKind.ViewVersions,
+			PermissionKind.ViewFormPages, PermissionKind.Open, PermissionKind.ViewPages, PermissionKind.CreateSSCSite,
+			PermissionKind.BrowseUserInfo, PermissionKind.UseClientIntegration, PermissionKind.UseRemoteAPIs,
+			PermissionKind.CreateAlerts,
+		}
+		if mask := EncodePermissions(kinds...); mask != readPermissions {
+			t.Errorf("incorrect encoded permissions: %+v", mask)
+		}
+		for _, kind := range kinds {
+			if !HasPermissions(EncodePermissions(kind), kind) {
+				t.Errorf("encoded mask should have %d permissions", kind)
+			}
+		}
+		if mask := EncodePermissions(PermissionKind.FullMask); !HasPermissions(mask, PermissionKind.FullMask) {
+			t.Errorf("incorrect encoded full permissions: %+v", mask)
+		}
+		if mask := EncodePermissions(); mask != (BasePermissions{}) {
+			t.Errorf("incorrect encoded empty permissions: %+v", mask)
+		}
+
+	})
+
+	t.Run("ParseBasePermissions", func(t *testing.T) {
+
+		verbose := []byte(`{"d":{"GetUserEffectivePermissions":{"__metadata":{"type":"SP.BasePermissions"},"High":"432","Low":"1011030767"}}}`)
+		minimal := []byte(`{"High":"432","Low":"1011030767"}`)
+		for _, data := range [][]byte{verbose, minimal} {
+			permissions, err := parseBasePermissions(data, "GetUserEffectivePermissions")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *permissions != editPermissions {
+				t.Errorf("incorrect permissions: %+v", permissions)
+			}
+		}
+
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+
+	t.Run("EffectivePermissions", func(t *testing.T) {
+		current, err := web.EffectiveBasePermissions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !HasPermissions(*current, PermissionKind.ViewPages) {
+			t.Error("current user should be able to view pages")
+		}
+		user, err := web.CurrentUser().Select("LoginName").Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		can, err := CanUser(web, user.Data().LoginName, PermissionKind.ViewPages)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !can {
+			t.Error("current user should be able to view pages")
+		}
 	})
 
 }
@@ -0,0 +1,257 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ProfilesExportOptions provides optional settings for Profiles.Export method
+type ProfilesExportOptions struct {
+	Concurrency int      // number of parallel requests, 5 by default
+	Properties  []string // profile properties to export, all properties are exported by default
+}
+
+// ProfilesExportResult profiles export result
+type ProfilesExportResult struct {
+	Profiles []*ProfileExport // exported profiles in the order of the requested accounts
+	Errors   []*ProfileError  // per account failures, the export continues for the rest
+}
+
+// ProfileExport exported profile properties
+type ProfileExport struct {
+	AccountName string            // requested account login name
+	Properties  map[string]string // profile properties values mapped by names
+}
+
+// ProfilesSyncOptions provides optional settings for Profiles.Sync method
+type ProfilesSyncOptions struct {
+	DryRun      bool     // only plan the changes without applying
+	Concurrency int      // number of parallel accounts processing, 5 by default
+	MultiValued []string // multi-valued properties names, values are `|` separated, known SPS- multi-valued properties by default
+}
+
+// ProfilesSyncResult describes planned or applied profile properties changes
+type ProfilesSyncResult struct {
+	DryRun  bool                     // changes were only planned
+	Changes []*ProfilePropertyChange // properties changed (or to be changed)
+	Errors  []*ProfileError          // per account or property failures, the sync continues for the rest
+}
+
+// ProfilePropertyChange profile property value change
+type ProfilePropertyChange struct {
+	AccountName string // account login name
+	Property    string // property name
+	OldValue    string // current value
+	NewValue    string // desired value
+}
+
+// ProfileError describes a failure for an account during profiles batch operations
+type ProfileError struct {
+	AccountName string // account login name
+	Property    string // property name, empty for the account level failures
+	Operation   string // "get" or "set"
+	Err         error  // underlying error
+}
+
+// Error implements error interface
+func (e *ProfileError) Error() string {
+	if e.Property != "" {
+		return fmt.Sprintf("unable to %s %s property of %s: %s", e.Operation, e.Property, e.AccountName, e.Err)
+	}
+	return fmt.Sprintf("unable to %s %s profile: %s", e.Operation, e.AccountName, e.Err)
+}
+
+// Unwrap gets the underlying error
+func (e *ProfileError) Unwrap() error {
+	return e.Err
+}
+
+// defaultMultiValuedProfileProps are known multi-valued user profile properties
+var defaultMultiValuedProfileProps = []string{
+	"SPS-Skills",
+	"SPS-PastProjects",
+	"SPS-Responsibility",
+	"SPS-School",
+	"SPS-Interests",
+	"SPS-SipAddress",
+}
+
+// Property gets user profile property value by its name, multi-valued properties are `|` separated
+func (props *ProfilePropsInto) Property(name string) string {
+	for _, p := range props.UserProfileProperties {
+		if p.Key == name {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// Properties gets user profile properties values mapped by names
+func (props *ProfilePropsInto) Properties() map[string]string {
+	res := map[string]string{}
+	for _, p := range props.UserProfileProperties {
+		res[p.Key] = p.Value
+	}
+	return res
+}
+
+// Department gets user profile Department property value
+func (props *ProfilePropsInto) Department() string {
+	return props.Property("Department")
+}
+
+// Manager gets user profile Manager property value, the manager account name
+func (props *ProfilePropsInto) Manager() string {
+	return props.Property("Manager")
+}
+
+// Skills gets user profile SPS-Skills property values
+func (props *ProfilePropsInto) Skills() []string {
+	return splitProfileValues(props.Property("SPS-Skills"))
+}
+
+// Export exports profile properties of the accounts with limited concurrency,
+// failed accounts are reported in the result Errors
+func (profiles *Profiles) Export(loginNames []string, options *ProfilesExportOptions) *ProfilesExportResult {
+	if options == nil {
+		options = &ProfilesExportOptions{}
+	}
+	result := &ProfilesExportResult{}
+	exported := make([]*ProfileExport, len(loginNames))
+	var mu sync.Mutex
+	runBounded(profilesConcurrency(options.Concurrency), len(loginNames), func(i int) {
+		props, err := profiles.getProperties(loginNames[i])
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			result.Errors = append(result.Errors, &ProfileError{AccountName: loginNames[i], Operation: "get", Err: err})
+			return
+		}
+		if len(options.Properties) > 0 {
+			selected := map[string]string{}
+			for _, name := range options.Properties {
+				selected[name] = props[name]
+			}
+			props = selected
+		}
+		exported[i] = &ProfileExport{AccountName: loginNames[i], Properties: props}
+	})
+	for _, p := range exported {
+		if p != nil {
+			result.Profiles = append(result.Profiles, p)
+		}
+	}
+	return result
+}
+
+// Sync makes profile properties match the desired values mapped by account and property names,
+// only the changed properties are written, failures are reported in the result Errors
+func (profiles *Profiles) Sync(desired map[string]map[string]string, options *ProfilesSyncOptions) *ProfilesSyncResult {
+	if options == nil {
+		options = &ProfilesSyncOptions{}
+	}
+	multiValued := map[string]bool{}
+	multiValuedProps := options.MultiValued
+	if multiValuedProps == nil {
+		multiValuedProps = defaultMultiValuedProfileProps
+	}
+	for _, name := range multiValuedProps {
+		multiValued[name] = true
+	}
+
+	accounts := make([]string, 0, len(desired))
+	for account := range desired {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+
+	result := &ProfilesSyncResult{DryRun: options.DryRun}
+	var mu sync.Mutex
+	runBounded(profilesConcurrency(options.Concurrency), len(accounts), func(i int) {
+		account := accounts[i]
+		current, err := profiles.getProperties(account)
+		if err != nil {
+			mu.Lock()
+			result.Errors = append(result.Errors, &ProfileError{AccountName: account, Operation: "get", Err: err})
+			mu.Unlock()
+			return
+		}
+		for _, change := range diffProfileProps(account, current, desired[account], multiValued) {
+			if !options.DryRun {
+				if multiValued[change.Property] {
+					err = profiles.SetMultiValuedProfileProperty(account, change.Property, splitProfileValues(change.NewValue))
+				} else {
+					err = profiles.SetSingleValueProfileProperty(account, change.Property, change.NewValue)
+				}
+			}
+			mu.Lock()
+			if err != nil {
+				result.Errors = append(result.Errors, &ProfileError{AccountName: account, Property: change.Property, Operation: "set", Err: err})
+			} else {
+				result.Changes = append(result.Changes, change)
+			}
+			mu.Unlock()
+		}
+	})
+	return result
+}
+
+// getProperties gets profile properties of the account mapped by names
+func (profiles *Profiles) getProperties(loginName string) (map[string]string, error) {
+	data, err := profiles.GetPropertiesFor(loginName)
+	if err != nil {
+		return nil, err
+	}
+	props := data.Data()
+	if props.AccountName == "" && len(props.UserProfileProperties) == 0 {
+		return nil, fmt.Errorf("profile not found")
+	}
+	return props.Properties(), nil
+}
+
+// diffProfileProps gets changes of the desired properties sorted by names,
+// multi-valued properties are compared ignoring the values order
+func diffProfileProps(account string, current map[string]string, desired map[string]string, multiValued map[string]bool) []*ProfilePropertyChange {
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []*ProfilePropertyChange
+	for _, name := range names {
+		oldValue, newValue := current[name], desired[name]
+		same := oldValue == newValue
+		if multiValued[name] {
+			a, b := splitProfileValues(oldValue), splitProfileValues(newValue)
+			sort.Strings(a)
+			sort.Strings(b)
+			same = strings.Join(a, "|") == strings.Join(b, "|")
+		}
+		if !same {
+			changes = append(changes, &ProfilePropertyChange{AccountName: account, Property: name, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	return changes
+}
+
+// splitProfileValues splits `|` separated multi-valued property value
+func splitProfileValues(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, "|") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// profilesConcurrency gets batch operations concurrency, 5 by default
+func profilesConcurrency(concurrency int) int {
+	if concurrency <= 0 {
+		return 5
+	}
+	return concurrency
+}
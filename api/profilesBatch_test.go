@@ -0,0 +1,90 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfilesBatch(t *testing.T) {
+
+	t.Run("PropsAccessors", func(t *testing.T) {
+		props := &ProfilePropsInto{
+			UserProfileProperties: []*TypedKeyValue{
+				{Key: "Department", Value: "IT"},
+				{Key: "Manager", Value: "i:0#.f|membership|boss@contoso.com"},
+				{Key: "SPS-Skills", Value: "Go| SharePoint |"},
+			},
+		}
+		if props.Department() != "IT" {
+			t.Errorf("unexpected department: %s", props.Department())
+		}
+		if props.Manager() != "i:0#.f|membership|boss@contoso.com" {
+			t.Errorf("unexpected manager: %s", props.Manager())
+		}
+		if !reflect.DeepEqual(props.Skills(), []string{"Go", "SharePoint"}) {
+			t.Errorf("unexpected skills: %v", props.Skills())
+		}
+		if props.Property("Missing") != "" || len(props.Properties()) != 3 {
+			t.Error("unexpected properties")
+		}
+	})
+
+	t.Run("DiffProfileProps", func(t *testing.T) {
+		current := map[string]string{
+			"Department": "IT",
+			"SPS-Skills": "Go|SharePoint",
+			"AboutMe":    "Old",
+		}
+		desired := map[string]string{
+			"Department": "IT",
+			"SPS-Skills": "SharePoint|Go",
+			"AboutMe":    "New",
+			"Office":     "42",
+		}
+		changes := diffProfileProps("user", current, desired, map[string]bool{"SPS-Skills": true})
+		if len(changes) != 2 || changes[0].Property != "AboutMe" || changes[1].Property != "Office" {
+			t.Errorf("unexpected changes: %+v", changes)
+		}
+		if changes[0].OldValue != "Old" || changes[0].NewValue != "New" {
+			t.Errorf("unexpected change values: %+v", changes[0])
+		}
+	})
+
+	checkClient(t)
+
+	sp := NewSP(spClient)
+	profiles := sp.Profiles()
+	user, err := sp.Web().CurrentUser().Select("LoginName").Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown := "i:0#.f|membership|unknown@nowhere.local"
+
+	t.Run("Export", func(t *testing.T) {
+		res := profiles.Export([]string{user.Data().LoginName, unknown}, &ProfilesExportOptions{
+			Properties: []string{"AccountName", "Department"},
+		})
+		if len(res.Profiles) != 1 || res.Profiles[0].Properties["AccountName"] == "" {
+			t.Errorf("unexpected exported profiles: %+v", res.Profiles)
+		}
+		if len(res.Errors) != 1 || res.Errors[0].AccountName != unknown {
+			t.Errorf("unknown account should have been reported, got %+v", res.Errors)
+		}
+	})
+
+	t.Run("Sync", func(t *testing.T) {
+		desired := map[string]map[string]string{
+			user.Data().LoginName: {"AboutMe": "Synced from Gosip"},
+		}
+		if res := profiles.Sync(desired, nil); len(res.Errors) > 0 {
+			t.Fatal(res.Errors[0])
+		}
+		res := profiles.Sync(desired, &ProfilesSyncOptions{DryRun: true})
+		if len(res.Errors) > 0 {
+			t.Fatal(res.Errors[0])
+		}
+		if len(res.Changes) != 0 {
+			t.Errorf("profile should have been in sync, got %+v", res.Changes)
+		}
+	})
+}
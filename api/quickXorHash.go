@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/base64"
+	"hash"
+	"io"
+)
+
+// QuickXorHashSize is the size of QuickXorHash checksum in bytes
+const QuickXorHashSize = 20
+
+const (
+	quickXorHashWidth = QuickXorHashSize * 8 // 160 bits
+	quickXorHashShift = 11
+)
+
+// quickXorHash implements QuickXorHash algorithm used by SharePoint Online and OneDrive for file content hashes
+type quickXorHash struct {
+	data        [3]uint64
+	shiftSoFar  int
+	lengthSoFar uint64
+}
+
+// NewQuickXorHash creates QuickXorHash hash.Hash, SharePoint Online hash values are base64 encoded sums
+func NewQuickXorHash() hash.Hash {
+	return &quickXorHash{}
+}
+
+// QuickXorHashOf calculates base64 encoded QuickXorHash of the reader content
+func QuickXorHashOf(r io.Reader) (string, error) {
+	h := NewQuickXorHash()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+// Write xors the bytes into the 160 bits state shifting each next byte by 11 bits
+func (h *quickXorHash) Write(p []byte) (int, error) {
+	size := len(p)
+	cell := h.shiftSoFar / 64
+	offset := h.shiftSoFar % 64
+	iterations := size
+	if iterations > quickXorHashWidth {
+		iterations = quickXorHashWidth
+	}
+	for i := 0; i < iterations; i++ {
+		isLastCell := cell == len(h.data)-1
+		bitsInCell := 64
+		if isLastCell {
+			bitsInCell = quickXorHashWidth % 64
+		}
+		// Bytes at the same position modulo the width share the same shift
+		var xored byte
+		for j := i; j < size; j += quickXorHashWidth {
+			xored ^= p[j]
+		}
+		if offset <= bitsInCell-8 {
+			h.data[cell] ^= uint64(xored) << uint(offset)
+		} else {
+			next := cell + 1
+			if isLastCell {
+				next = 0
+			}
+			h.data[cell] ^= uint64(xored) << uint(offset)
+			h.data[next] ^= uint64(xored) >> uint(bitsInCell-offset)
+		}
+		offset += quickXorHashShift
+		for offset >= bitsInCell {
+			if isLastCell {
+				cell = 0
+			} else {
+				cell++
+			}
+			offset -= bitsInCell
+		}
+	}
+	h.shiftSoFar = (h.shiftSoFar + quickXorHashShift*(size%quickXorHashWidth)) % quickXorHashWidth
+	h.lengthSoFar += uint64(size)
+	return size, nil
+}
+
+// Sum appends the checksum to b, the content length is xored into the last 8 bytes
+func (h *quickXorHash) Sum(b []byte) []byte {
+	sum := make([]byte, QuickXorHashSize)
+	for i, cell := range h.data {
+		for j := 0; j < 8 && i*8+j < QuickXorHashSize; j++ {
+			sum[i*8+j] = byte(cell >> uint(8*j))
+		}
+	}
+	for i := 0; i < 8; i++ {
+		sum[QuickXorHashSize-8+i] ^= byte(h.lengthSoFar >> uint(8*i))
+	}
+	return append(b, sum...)
+}
+
+// Reset resets the hash to its initial state
+func (h *quickXorHash) Reset() {
+	*h = quickXorHash{}
+}
+
+// Size returns the number of bytes Sum will return
+func (h *quickXorHash) Size() int {
+	return QuickXorHashSize
+}
+
+// BlockSize returns the hash's underlying block size
+func (h *quickXorHash) BlockSize() int {
+	return 64
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"math/rand"
+	"testing"
+)
+
+func TestQuickXorHash(t *testing.T) {
+
+	// reference bitwise implementation: byte k is xored at bit position k*11 modulo 160
+	reference := func(data []byte) string {
+		sum := make([]byte, QuickXorHashSize)
+		for k, b := range data {
+			pos := (k * 11) % 160
+			for bit := 0; bit < 8; bit++ {
+				if b&(1<<uint(bit)) != 0 {
+					n := (pos + bit) % 160
+					sum[n/8] ^= 1 << uint(n%8)
+				}
+			}
+		}
+		length := uint64(len(data))
+		for i := 0; i < 8; i++ {
+			sum[12+i] ^= byte(length >> uint(8*i))
+		}
+		return base64.StdEncoding.EncodeToString(sum)
+	}
+
+	t.Run("Vectors", func(t *testing.T) {
+		vectors := map[string]string{
+			"":  "AAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+			"J": "SgAAAAAAAAAAAAAAAQAAAAAAAAA=",
+		}
+		for input, expected := range vectors {
+			hash, err := QuickXorHashOf(bytes.NewBufferString(input))
+			if err != nil {
+				t.Error(err)
+			}
+			if hash != expected {
+				t.Errorf("incorrect hash of \"%s\", expected %s, got %s", input, expected, hash)
+			}
+		}
+	})
+
+	t.Run("Reference", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(1))
+		for _, size := range []int{1, 2, 7, 20, 159, 160, 161, 320, 1000, 65537} {
+			data := make([]byte, size)
+			rnd.Read(data)
+			hash, _ := QuickXorHashOf(bytes.NewReader(data))
+			if expected := reference(data); hash != expected {
+				t.Errorf("incorrect hash of %d bytes, expected %s, got %s", size, expected, hash)
+			}
+		}
+	})
+
+	t.Run("Chunked", func(t *testing.T) {
+		rnd := rand.New(rand.NewSource(2))
+		data := make([]byte, 10000)
+		rnd.Read(data)
+		h := NewQuickXorHash()
+		for pos := 0; pos < len(data); {
+			n := rnd.Intn(400) + 1
+			if pos+n > len(data) {
+				n = len(data) - pos
+			}
+			_, _ = h.Write(data[pos : pos+n])
+			pos += n
+		}
+		if hash := base64.StdEncoding.EncodeToString(h.Sum(nil)); hash != reference(data) {
+			t.Errorf("chunked writes hash mismatch")
+		}
+		h.Reset()
+		if hash := base64.StdEncoding.EncodeToString(h.Sum(nil)); hash != reference(nil) {
+			t.Errorf("reset hash mismatch")
+		}
+	})
+
+}
@@ -55,3 +55,36 @@ func (recycleBinResp *RecycleBinResp) Normalized() []byte {
 	normalized, _ := NormalizeODataCollection(*recycleBinResp)
 	return normalized
 }
+
+/* Pagination helpers */
+
+// RecycleBinPager - RecycleBin collection pages iterator
+type RecycleBinPager struct {
+	*Pager
+}
+
+// Pager gets RecycleBin collection pages iterator, the iteration follows OData next page links
+func (recycleBin *RecycleBin) Pager() *RecycleBinPager {
+	return &RecycleBinPager{NewPager(recycleBin.client, recycleBin.ToURL(), recycleBin.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *RecycleBinPager) Prefetch(enabled bool) *RecycleBinPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *RecycleBinPager) Page() RecycleBinResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (recycleBinResp *RecycleBinResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*recycleBinResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (recycleBinResp *RecycleBinResp) HasNextPage() bool {
+	return recycleBinResp.NextPageURL() != ""
+}
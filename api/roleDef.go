@@ -1,8 +1,10 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/pnocera/gosip"
 )
@@ -35,6 +37,14 @@ type RoleDefInfo struct {
 	RoleTypeKind    int              `json:"RoleTypeKind"`
 }
 
+// RoleDefCreationInfo role definition metadata, use EncodePermissions to build BasePermissions from PermissionKind values
+type RoleDefCreationInfo struct {
+	Name            string           // Role definition name, e.g. "Contribute without delete"
+	Description     string           // Description text
+	Order           int              // Order in the permission levels list, optional
+	BasePermissions *BasePermissions // Permissions mask
+}
+
 // GetByID gets a role definition by its ID
 func (def *RoleDefinitions) GetByID(roleDefID int) (*RoleDefInfo, error) {
 	endpoint := fmt.Sprintf("%s/GetById(%d)", def.endpoint, roleDefID)
@@ -43,7 +53,7 @@ func (def *RoleDefinitions) GetByID(roleDefID int) (*RoleDefInfo, error) {
 
 // GetByName gets a role definition by its Name
 func (def *RoleDefinitions) GetByName(roleDefName string) (*RoleDefInfo, error) {
-	endpoint := fmt.Sprintf("%s/GetByName('%s')", def.endpoint, roleDefName)
+	endpoint := fmt.Sprintf("%s/GetByName('%s')", def.endpoint, escapeODataString(roleDefName))
 	return getRoleDef(def, endpoint)
 }
 
@@ -75,6 +85,88 @@ func (def *RoleDefinitions) Get() ([]*RoleDefInfo, error) {
 	return res.D.Results, nil
 }
 
+// Add creates a role definition (permission level)
+func (def *RoleDefinitions) Add(info *RoleDefCreationInfo) (*RoleDefInfo, error) {
+	body, _ := json.Marshal(info.toMetadata())
+	client := NewHTTPClient(def.client)
+	data, err := client.Post(def.endpoint, bytes.NewBuffer(body), patchConfigHeaders(def.config, HeadersPresets.Verbose.Headers))
+	if err != nil {
+		return nil, err
+	}
+	res := &struct {
+		RoleDefInfo *RoleDefInfo `json:"d"`
+	}{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return res.RoleDefInfo, nil
+}
+
+// Update updates the role definition, empty properties of `info` are not updated
+func (def *RoleDefinitions) Update(roleDefID int, info *RoleDefCreationInfo) error {
+	body, _ := json.Marshal(info.toMetadata())
+	endpoint := fmt.Sprintf("%s/GetById(%d)", def.endpoint, roleDefID)
+	client := NewHTTPClient(def.client)
+	_, err := client.Update(endpoint, bytes.NewBuffer(body), def.config)
+	return err
+}
+
+// Delete deletes the role definition, the definition is removed from all role assignments
+func (def *RoleDefinitions) Delete(roleDefID int) error {
+	endpoint := fmt.Sprintf("%s/GetById(%d)", def.endpoint, roleDefID)
+	client := NewHTTPClient(def.client)
+	_, err := client.Delete(endpoint, def.config)
+	return err
+}
+
+// Ensure creates the role definition or updates the existing one with the same name,
+// so custom permission levels can be provisioned consistently across sites
+func (def *RoleDefinitions) Ensure(info *RoleDefCreationInfo) (*RoleDefInfo, error) {
+	defs, err := def.Get()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range defs {
+		if d.Name != info.Name {
+			continue
+		}
+		sameDescription := info.Description == "" || info.Description == d.Description
+		samePermissions := info.BasePermissions == nil || (d.BasePermissions != nil && *info.BasePermissions == *d.BasePermissions)
+		if sameDescription && samePermissions {
+			return d, nil
+		}
+		if err := def.Update(d.ID, info); err != nil {
+			return nil, err
+		}
+		return def.GetByID(d.ID)
+	}
+	return def.Add(info)
+}
+
+// toMetadata gets SP.RoleDefinition payload, empty properties are omitted
+func (info *RoleDefCreationInfo) toMetadata() map[string]interface{} {
+	metadata := map[string]interface{}{
+		"__metadata": map[string]string{"type": "SP.RoleDefinition"},
+	}
+	if info.Name != "" {
+		metadata["Name"] = info.Name
+	}
+	if info.Description != "" {
+		metadata["Description"] = info.Description
+	}
+	if info.Order != 0 {
+		metadata["Order"] = info.Order
+	}
+	if info.BasePermissions != nil {
+		metadata["BasePermissions"] = map[string]interface{}{
+			"__metadata": map[string]string{"type": "SP.BasePermissions"},
+			"High":       strconv.FormatInt(info.BasePermissions.High, 10),
+			"Low":        strconv.FormatInt(info.BasePermissions.Low, 10),
+		}
+	}
+	return metadata
+}
+
 func getRoleDef(def *RoleDefinitions, endpoint string) (*RoleDefInfo, error) {
 	client := NewHTTPClient(def.client)
 
@@ -2,6 +2,8 @@ package api
 
 import (
 	"testing"
+
+	"github.com/google/uuid"
 )
 
 func TestRoleDefinitions(t *testing.T) {
@@ -61,4 +63,43 @@ func TestRoleDefinitions(t *testing.T) {
 		}
 	})
 
+	t.Run("AddUpdateDelete", func(t *testing.T) {
+		mask := EncodePermissions(
+			PermissionKind.ViewListItems,
+			PermissionKind.AddListItems,
+			PermissionKind.EditListItems,
+			PermissionKind.OpenItems,
+			PermissionKind.ViewVersions,
+			PermissionKind.ViewFormPages,
+			PermissionKind.Open,
+			PermissionKind.ViewPages,
+			PermissionKind.BrowseUserInfo,
+		)
+		info := &RoleDefCreationInfo{
+			Name:            "Contribute without delete " + uuid.New().String(),
+			Description:     "Created by tests",
+			BasePermissions: &mask,
+		}
+		def, err := web.RoleDefinitions().Add(info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if HasPermissions(*def.BasePermissions, PermissionKind.DeleteListItems) {
+			t.Error("role definition should not allow deleting")
+		}
+
+		info.Description = "Updated by tests"
+		ensured, err := web.RoleDefinitions().Ensure(info)
+		if err != nil {
+			t.Error(err)
+		}
+		if ensured.ID != def.ID || ensured.Description != info.Description {
+			t.Errorf("role definition was not updated: %+v", ensured)
+		}
+
+		if err := web.RoleDefinitions().Delete(def.ID); err != nil {
+			t.Error(err)
+		}
+	})
+
 }
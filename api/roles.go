@@ -117,5 +117,24 @@ func (permissions *Roles) RemoveAssigment(principalID int, roleDefID int) error
 	return err
 }
 
+// GetAssigments gets role assigments of this securable object with members and role definitions
+func (permissions *Roles) GetAssigments() ([]*RoleAssigment, error) {
+	endpoint := fmt.Sprintf("%s/RoleAssignments?$expand=Member,RoleDefinitionBindings", permissions.endpoint)
+	var assigments []*RoleAssigment
+	pager := NewPager(permissions.client, endpoint, permissions.config)
+	for pager.Next() {
+		collection, _ := NormalizeODataCollection(pager.Page())
+		var page []*RoleAssigment
+		if err := json.Unmarshal(collection, &page); err != nil {
+			return nil, fmt.Errorf("unable to parse the response: %w", err)
+		}
+		assigments = append(assigments, page...)
+	}
+	if err := pager.Err(); err != nil {
+		return nil, err
+	}
+	return assigments, nil
+}
+
 // ToDo:
 // Has permissions helper method
@@ -0,0 +1,267 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RolesSpec describes desired permissions of a securable object
+type RolesSpec struct {
+	Unique              bool               // the object has unique permissions, false resets the inheritance
+	CopyRoleAssignments bool               // copy parent assignments when the inheritance is broken, the copies not declared in Bindings are removed unless KeepExtra is set
+	ClearSubScopes      bool               // make child objects inherit the permissions when the inheritance is broken
+	KeepExtra           bool               // do not remove existing bindings which are not declared in Bindings
+	Bindings            []*RoleBindingSpec // desired role bindings of the object with unique permissions
+}
+
+// RoleBindingSpec describes a desired role binding, either User or Group and either RoleName or RoleType should be provided
+type RoleBindingSpec struct {
+	User     string // user login name, the user is resolved with Web.EnsureUser
+	Group    string // SharePoint group name
+	RoleName string // role definition name, e.g. "Full Control"
+	RoleType int    // role definition type (use RoleTypeKinds struct helper as enumerator), is used when RoleName is empty
+}
+
+// RoleBindingChange describes a resolved role binding
+type RoleBindingChange struct {
+	PrincipalID   int    // user or group ID
+	PrincipalName string // user login name or group title
+	RoleDefID     int    // role definition ID
+	RoleName      string // role definition name
+}
+
+// RolesPlan describes changes required to reach the desired permissions state
+type RolesPlan struct {
+	BreakInheritance bool                 // the inheritance should be broken
+	ResetInheritance bool                 // the inheritance should be restored
+	Add              []*RoleBindingChange // missing bindings
+	Remove           []*RoleBindingChange // extra bindings
+
+	spec    *RolesSpec
+	desired []*RoleBindingChange
+}
+
+// IsEmpty checks if the object is already in the desired state
+func (plan *RolesPlan) IsEmpty() bool {
+	return !plan.BreakInheritance && !plan.ResetInheritance && len(plan.Add) == 0 && len(plan.Remove) == 0
+}
+
+// String gets human readable plan, a line per change
+func (plan *RolesPlan) String() string {
+	var lines []string
+	if plan.ResetInheritance {
+		lines = append(lines, "~ reset inheritance")
+	}
+	if plan.BreakInheritance {
+		lines = append(lines, "~ break inheritance")
+	}
+	for _, b := range plan.Add {
+		lines = append(lines, fmt.Sprintf("+ %s: %s", b.PrincipalName, b.RoleName))
+	}
+	for _, b := range plan.Remove {
+		lines = append(lines, fmt.Sprintf("- %s: %s", b.PrincipalName, b.RoleName))
+	}
+	if len(lines) == 0 {
+		return "no changes"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Plan compares the desired permissions state with the current one and gets the changes plan, nothing is modified
+func (permissions *Roles) Plan(spec *RolesSpec) (*RolesPlan, error) {
+	plan := &RolesPlan{spec: spec}
+	unique, err := permissions.HasUniqueAssignments()
+	if err != nil {
+		return nil, err
+	}
+
+	if !spec.Unique {
+		if len(spec.Bindings) > 0 {
+			return nil, fmt.Errorf("role bindings can't be declared for an object inheriting permissions")
+		}
+		plan.ResetInheritance = unique
+		return plan, nil
+	}
+
+	if plan.desired, err = permissions.resolveBindings(spec.Bindings); err != nil {
+		return nil, err
+	}
+
+	var current []*RoleAssigment
+	if unique || spec.CopyRoleAssignments {
+		if current, err = permissions.GetAssigments(); err != nil {
+			return nil, err
+		}
+	}
+	plan.BreakInheritance = !unique
+	plan.Add, plan.Remove = diffRoleBindings(current, plan.desired, spec.KeepExtra)
+	if plan.BreakInheritance && !spec.CopyRoleAssignments {
+		granted, err := permissions.currentUserFullControl()
+		if err != nil {
+			return nil, err
+		}
+		plan.Add, plan.Remove = withGrantedBinding(plan.Add, plan.Remove, granted, spec.KeepExtra)
+	}
+	return plan, nil
+}
+
+// Apply applies the changes plan received with Plan method, only the planned changes are made.
+// New bindings are added before the extra ones are removed to not lock out the principals in the middle.
+func (permissions *Roles) Apply(plan *RolesPlan) error {
+	if plan.ResetInheritance {
+		return permissions.ResetInheritance()
+	}
+	if plan.BreakInheritance {
+		if err := permissions.BreakInheritance(plan.spec.CopyRoleAssignments, plan.spec.ClearSubScopes); err != nil {
+			return err
+		}
+	}
+	for _, b := range plan.Add {
+		if err := permissions.AddAssigment(b.PrincipalID, b.RoleDefID); err != nil {
+			return fmt.Errorf("unable to add %s: %s binding: %w", b.PrincipalName, b.RoleName, err)
+		}
+	}
+	for _, b := range plan.Remove {
+		if err := permissions.RemoveAssigment(b.PrincipalID, b.RoleDefID); err != nil {
+			return fmt.Errorf("unable to remove %s: %s binding: %w", b.PrincipalName, b.RoleName, err)
+		}
+	}
+	return nil
+}
+
+// Reconcile brings the object permissions to the desired state, with `dryRun` the plan is returned without applying
+func (permissions *Roles) Reconcile(spec *RolesSpec, dryRun bool) (*RolesPlan, error) {
+	plan, err := permissions.Plan(spec)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun || plan.IsEmpty() {
+		return plan, nil
+	}
+	return plan, permissions.Apply(plan)
+}
+
+// resolveBindings resolves principals and role definitions of the bindings
+func (permissions *Roles) resolveBindings(bindings []*RoleBindingSpec) ([]*RoleBindingChange, error) {
+	web := NewWeb(permissions.client, getPriorEndpoint(permissions.endpoint, "/_api")+"/_api/Web", permissions.config)
+	roleDefs := map[string]*RoleDefInfo{}
+	var resolved []*RoleBindingChange
+	for _, b := range bindings {
+		change := &RoleBindingChange{}
+		switch {
+		case b.User != "" && b.Group != "":
+			return nil, fmt.Errorf("either user or group should be provided in a binding, got both %s and %s", b.User, b.Group)
+		case b.User != "":
+			user, err := web.EnsureUser(b.User)
+			if err != nil {
+				return nil, fmt.Errorf("unable to resolve user %s: %w", b.User, err)
+			}
+			change.PrincipalID = user.ID
+			change.PrincipalName = user.LoginName
+		case b.Group != "":
+			group, err := web.SiteGroups().GetByName(b.Group).Select("Id,Title").Get()
+			if err != nil {
+				return nil, fmt.Errorf("unable to resolve group %s: %w", b.Group, err)
+			}
+			change.PrincipalID = group.Data().ID
+			change.PrincipalName = group.Data().Title
+		default:
+			return nil, fmt.Errorf("either user or group should be provided in a binding")
+		}
+
+		key := fmt.Sprintf("%s#%d", b.RoleName, b.RoleType)
+		roleDef, ok := roleDefs[key]
+		if !ok {
+			var err error
+			if b.RoleName != "" {
+				roleDef, err = web.RoleDefinitions().GetByName(b.RoleName)
+			} else {
+				roleDef, err = web.RoleDefinitions().GetByType(b.RoleType)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("unable to resolve role definition %s: %w", key, err)
+			}
+			roleDefs[key] = roleDef
+		}
+		change.RoleDefID = roleDef.ID
+		change.RoleName = roleDef.Name
+		resolved = append(resolved, change)
+	}
+	return resolved, nil
+}
+
+// currentUserFullControl gets the Full Control binding of the current user,
+// SharePoint grants it when the inheritance is broken without copying the assignments
+func (permissions *Roles) currentUserFullControl() (*RoleBindingChange, error) {
+	web := NewWeb(permissions.client, getPriorEndpoint(permissions.endpoint, "/_api")+"/_api/Web", permissions.config)
+	user, err := web.CurrentUser().Select("Id,LoginName").Get()
+	if err != nil {
+		return nil, err
+	}
+	roleDef, err := web.RoleDefinitions().GetByType(RoleTypeKinds.Administrator)
+	if err != nil {
+		return nil, err
+	}
+	return &RoleBindingChange{
+		PrincipalID:   user.Data().ID,
+		PrincipalName: user.Data().LoginName,
+		RoleDefID:     roleDef.ID,
+		RoleName:      roleDef.Name,
+	}, nil
+}
+
+// withGrantedBinding accounts the binding granted by SharePoint when the inheritance is broken,
+// the declared binding is not added again and the undeclared one is removed unless `keepExtra` is set
+func withGrantedBinding(add []*RoleBindingChange, remove []*RoleBindingChange, granted *RoleBindingChange, keepExtra bool) ([]*RoleBindingChange, []*RoleBindingChange) {
+	declared := false
+	var rest []*RoleBindingChange
+	for _, b := range add {
+		if b.PrincipalID == granted.PrincipalID && b.RoleDefID == granted.RoleDefID {
+			declared = true
+			continue
+		}
+		rest = append(rest, b)
+	}
+	if !declared && !keepExtra {
+		remove = append(remove, granted)
+	}
+	return rest, remove
+}
+
+// diffRoleBindings gets missing and extra bindings, hidden role definitions (Limited Access) are managed by SharePoint and are kept
+func diffRoleBindings(current []*RoleAssigment, desired []*RoleBindingChange, keepExtra bool) ([]*RoleBindingChange, []*RoleBindingChange) {
+	key := func(principalID int, roleDefID int) string {
+		return fmt.Sprintf("%d:%d", principalID, roleDefID)
+	}
+	existing := map[string]bool{}
+	var add, remove []*RoleBindingChange
+	declared := map[string]bool{}
+	for _, b := range desired {
+		declared[key(b.PrincipalID, b.RoleDefID)] = true
+	}
+	for _, a := range current {
+		if a.Member == nil {
+			continue
+		}
+		for _, r := range a.RoleDefinitionBindings {
+			k := key(a.Member.ID, r.ID)
+			existing[k] = true
+			if declared[k] || keepExtra || r.Hidden {
+				continue
+			}
+			name := a.Member.LoginName
+			if a.Member.PrincipalType == 8 { // SharePoint group
+				name = a.Member.Title
+			}
+			remove = append(remove, &RoleBindingChange{PrincipalID: a.Member.ID, PrincipalName: name, RoleDefID: r.ID, RoleName: r.Name})
+		}
+	}
+	for _, b := range desired {
+		k := key(b.PrincipalID, b.RoleDefID)
+		if !existing[k] {
+			existing[k] = true
+			add = append(add, b)
+		}
+	}
+	return add, remove
+}
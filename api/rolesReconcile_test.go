@@ -0,0 +1,148 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRolesReconcile(t *testing.T) {
+
+	t.Run("DiffRoleBindings", func(t *testing.T) {
+		var current []*RoleAssigment
+		payload := []byte(`[
+			{"Member":{"Id":3,"LoginName":"Owners","PrincipalType":8,"Title":"Owners"},"RoleDefinitionBindings":[{"Id":1073741829,"Name":"Full Control"}]},
+			{"Member":{"Id":7,"LoginName":"i:0#.f|membership|user@contoso.com","PrincipalType":1,"Title":"User"},"RoleDefinitionBindings":[{"Id":1073741827,"Name":"Contribute"},{"Id":1073741825,"Name":"Limited Access","Hidden":true}]}
+		]`)
+		if err := json.Unmarshal(payload, &current); err != nil {
+			t.Fatal(err)
+		}
+		desired := []*RoleBindingChange{
+			{PrincipalID: 3, PrincipalName: "Owners", RoleDefID: 1073741829, RoleName: "Full Control"},
+			{PrincipalID: 7, PrincipalName: "i:0#.f|membership|user@contoso.com", RoleDefID: 1073741826, RoleName: "Read"},
+			{PrincipalID: 7, PrincipalName: "i:0#.f|membership|user@contoso.com", RoleDefID: 1073741826, RoleName: "Read"},
+		}
+
+		add, remove := diffRoleBindings(current, desired, false)
+		if len(add) != 1 || add[0].RoleName != "Read" {
+			t.Errorf("unexpected bindings to add: %+v", add)
+		}
+		if len(remove) != 1 || remove[0].RoleName != "Contribute" {
+			t.Errorf("unexpected bindings to remove: %+v", remove)
+		}
+
+		if _, remove := diffRoleBindings(current, desired, true); len(remove) != 0 {
+			t.Errorf("extra bindings should have been kept, got %+v", remove)
+		}
+
+		plan := &RolesPlan{BreakInheritance: true, Add: add, Remove: remove}
+		expected := "~ break inheritance\n" +
+			"+ i:0#.f|membership|user@contoso.com: Read\n" +
+			"- i:0#.f|membership|user@contoso.com: Contribute"
+		if plan.String() != expected {
+			t.Errorf("unexpected plan output:\n%s", plan)
+		}
+		if plan.IsEmpty() {
+			t.Error("plan should not be empty")
+		}
+		if (&RolesPlan{}).String() != "no changes" {
+			t.Error("empty plan should have no changes")
+		}
+	})
+
+	t.Run("WithGrantedBinding", func(t *testing.T) {
+		granted := &RoleBindingChange{PrincipalID: 7, PrincipalName: "i:0#.f|membership|user@contoso.com", RoleDefID: 1073741829, RoleName: "Full Control"}
+		read := &RoleBindingChange{PrincipalID: 7, PrincipalName: "i:0#.f|membership|user@contoso.com", RoleDefID: 1073741826, RoleName: "Read"}
+
+		add, remove := withGrantedBinding([]*RoleBindingChange{read}, nil, granted, false)
+		if len(add) != 1 || len(remove) != 1 || remove[0] != granted {
+			t.Errorf("undeclared granted binding should have been removed, got add %+v, remove %+v", add, remove)
+		}
+		if _, remove := withGrantedBinding([]*RoleBindingChange{read}, nil, granted, true); len(remove) != 0 {
+			t.Errorf("granted binding should have been kept, got %+v", remove)
+		}
+		add, remove = withGrantedBinding([]*RoleBindingChange{read, granted}, nil, granted, false)
+		if len(add) != 1 || add[0] != read || len(remove) != 0 {
+			t.Errorf("declared granted binding should not have been changed, got add %+v, remove %+v", add, remove)
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	newListTitle := uuid.New().String()
+	if _, err := web.Lists().Add(newListTitle, nil); err != nil {
+		t.Fatalf("can't create a list to test permissions: %s", err)
+	}
+	list := web.Lists().GetByTitle(newListTitle)
+	user, err := web.CurrentUser().Select("LoginName").Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec := &RolesSpec{
+		Unique: true,
+		Bindings: []*RoleBindingSpec{
+			{User: user.Data().LoginName, RoleType: RoleTypeKinds.Administrator},
+			{User: user.Data().LoginName, RoleType: RoleTypeKinds.Contributor},
+		},
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		plan, err := list.Roles().Reconcile(spec, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Full Control is granted to the current user by breaking the inheritance
+		if !plan.BreakInheritance || len(plan.Add) != 1 || len(plan.Remove) != 0 {
+			t.Errorf("unexpected plan:\n%s", plan)
+		}
+		unique, err := list.Roles().HasUniqueAssignments()
+		if err != nil {
+			t.Error(err)
+		}
+		if unique {
+			t.Error("dry run should not have modified permissions")
+		}
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		if _, err := list.Roles().Reconcile(spec, false); err != nil {
+			t.Fatal(err)
+		}
+		plan, err := list.Roles().Plan(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !plan.IsEmpty() {
+			t.Errorf("permissions should have been in the desired state, got plan:\n%s", plan)
+		}
+	})
+
+	t.Run("Inherit", func(t *testing.T) {
+		if _, err := list.Roles().Reconcile(&RolesSpec{}, false); err != nil {
+			t.Fatal(err)
+		}
+		unique, err := list.Roles().HasUniqueAssignments()
+		if err != nil {
+			t.Error(err)
+		}
+		if unique {
+			t.Error("inheritance should have been restored")
+		}
+	})
+
+	t.Run("InvalidSpec", func(t *testing.T) {
+		_, err := list.Roles().Plan(&RolesSpec{Bindings: spec.Bindings})
+		if err == nil || !strings.Contains(err.Error(), "inheriting") {
+			t.Error("bindings on an inheriting object should have been rejected")
+		}
+	})
+
+	// Post-configuration
+	if err := list.Delete(); err != nil {
+		t.Error(err)
+	}
+}
@@ -50,6 +50,26 @@ func TestRoles(t *testing.T) {
 		}
 	})
 
+	t.Run("GetAssigments", func(t *testing.T) {
+		assigments, err := list.Roles().GetAssigments()
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, a := range assigments {
+			if a.Member != nil && a.Member.ID == userID {
+				for _, r := range a.RoleDefinitionBindings {
+					if r.ID == roleDef.ID {
+						found = true
+					}
+				}
+			}
+		}
+		if !found {
+			t.Error("added assigment was not found")
+		}
+	})
+
 	t.Run("RemoveAssigment", func(t *testing.T) {
 		if err := list.Roles().RemoveAssigment(userID, roleDef.ID); err != nil {
 			t.Error(err)
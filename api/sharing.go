@@ -0,0 +1,447 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pnocera/gosip"
+)
+
+// Sharing represents SharePoint sharing links and invitations API of a securable item
+// Always use NewSharing constructor instead of &Sharing{}
+type Sharing struct {
+	client   *gosip.SPClient
+	config   *RequestConfig
+	endpoint string
+}
+
+// SharingLinkKinds - sharing link kinds enumerator
+var SharingLinkKinds = struct {
+	Uninitialized    int
+	Direct           int
+	OrganizationView int
+	OrganizationEdit int
+	AnonymousView    int
+	AnonymousEdit    int
+	Flexible         int
+}{
+	Uninitialized:    0,
+	Direct:           1,
+	OrganizationView: 2,
+	OrganizationEdit: 3,
+	AnonymousView:    4,
+	AnonymousEdit:    5,
+	Flexible:         6,
+}
+
+// SharingRoles - sharing roles enumerator
+var SharingRoles = struct {
+	None  int
+	View  int
+	Edit  int
+	Owner int
+}{
+	None:  0,
+	View:  1,
+	Edit:  2,
+	Owner: 3,
+}
+
+// SharingLinkOptions provides optional settings for a sharing link creation
+type SharingLinkOptions struct {
+	Expiration           time.Time // link expiration, zero value means no expiration, anonymous links only
+	Password             string    // link password, anonymous links only when supported by the tenant
+	Role                 int       // link role for Flexible links, see SharingRoles
+	AllowAnonymousAccess bool      // allow anonymous access for Flexible links
+}
+
+// SharingInfoOptions provides optional settings for sharing information requests
+type SharingInfoOptions struct {
+	MaxPrincipals  int // maximum number of principals the item is shared with to return, 100 by default
+	MaxLinkMembers int // maximum number of members to return per sharing link, 100 by default
+}
+
+// SharingLinkInfo sharing link details
+type SharingLinkInfo struct {
+	AllowsAnonymousAccess    bool                     `json:"AllowsAnonymousAccess"`
+	BlocksDownload           bool                     `json:"BlocksDownload"`
+	Created                  string                   `json:"Created"`
+	CreatedBy                *SharingPrincipalInfo    `json:"CreatedBy"`
+	Expiration               string                   `json:"Expiration"` // empty when the link doesn't expire
+	HasExternalGuestInvitees bool                     `json:"HasExternalGuestInvitees"`
+	IsActive                 bool                     `json:"IsActive"`
+	IsEditLink               bool                     `json:"IsEditLink"`
+	IsReviewLink             bool                     `json:"IsReviewLink"`
+	LastModified             string                   `json:"LastModified"`
+	LastModifiedBy           *SharingPrincipalInfo    `json:"LastModifiedBy"`
+	LinkKind                 int                      `json:"LinkKind"`
+	PasswordLastModified     string                   `json:"PasswordLastModified"`
+	RequiresPassword         bool                     `json:"RequiresPassword"`
+	ShareID                  string                   `json:"ShareId"`
+	URL                      string                   `json:"Url"`
+	Invitations              []*SharingLinkInvitation `json:"Invitations"`
+}
+
+// SharingLinkInvitation sharing link invitation
+type SharingLinkInvitation struct {
+	InvitedBy *SharingPrincipalInfo `json:"invitedBy"`
+	InvitedOn string                `json:"invitedOn"`
+	Invitee   *SharingPrincipalInfo `json:"invitee"`
+}
+
+// SharingPrincipalInfo sharing principal
+type SharingPrincipalInfo struct {
+	ID                int    `json:"id"`
+	Email             string `json:"email"`
+	IsActive          bool   `json:"isActive"`
+	IsExternal        bool   `json:"isExternal"`
+	LoginName         string `json:"loginName"`
+	Name              string `json:"name"`
+	PrincipalType     int    `json:"principalType"`
+	UserPrincipalName string `json:"userPrincipalName"`
+}
+
+// SharingLink sharing link with its members
+type SharingLink struct {
+	Details     *SharingLinkInfo        `json:"linkDetails"`
+	Members     []*SharingPrincipalInfo `json:"linkMembers"`
+	IsInherited bool                    `json:"isInherited"`
+}
+
+// SharingPrincipal principal the item is shared with
+type SharingPrincipal struct {
+	Principal   *SharingPrincipalInfo `json:"principal"`
+	Role        int                   `json:"role"` // see SharingRoles
+	IsInherited bool                  `json:"isInherited"`
+}
+
+// SharingInfo item sharing state
+type SharingInfo struct {
+	AnonymousLinkExpirationRestrictionDays int    `json:"anonymousLinkExpirationRestrictionDays"`
+	CanAddExternalPrincipal                bool   `json:"canAddExternalPrincipal"`
+	CanUseSimplifiedRoles                  bool   `json:"canUseSimplifiedRoles"`
+	DirectURL                              string `json:"directUrl"`
+	PermissionsInformation                 *struct {
+		HasInheritedLinks       bool                `json:"hasInheritedLinks"`
+		Links                   []*SharingLink      `json:"links"`
+		Principals              []*SharingPrincipal `json:"principals"`
+		TotalNumberOfPrincipals int                 `json:"totalNumberOfPrincipals"`
+	} `json:"permissionsInformation"`
+}
+
+// ShareObjectInfo invitation settings for ShareObject method
+type ShareObjectInfo struct {
+	LoginNames           []string // login names or emails of the invitees
+	RoleDefID            int      // role definition ID to grant, e.g. Contribute, is ignored when GroupID is provided
+	GroupID              int      // SharePoint group ID to add the invitees to
+	PropagateACL         bool     // propagate permissions to the item's children
+	SendEmail            bool     // send invitation email
+	IncludeAnonymousLink bool     // include anonymous link into the invitation email
+	EmailSubject         string   // invitation email subject
+	EmailBody            string   // invitation email body
+}
+
+// SharingResult ShareObject and Unshare methods result
+type SharingResult struct {
+	ErrorMessage               string `json:"ErrorMessage"`
+	Name                       string `json:"Name"`
+	PermissionsPageRelativeURL string `json:"PermissionsPageRelativeUrl"`
+	StatusCode                 int    `json:"StatusCode"` // negative codes are failures
+}
+
+// DocumentSharingInfo invitation settings for UpdateDocumentSharingInfo method
+type DocumentSharingInfo struct {
+	Recipients                 map[string]int // emails or login names mapped to roles, see SharingRoles
+	ValidateExistingPermission bool           // do not grant when the recipients already have the permissions
+	AdditiveMode               bool           // add the permissions to existing ones instead of replacing
+	SendNotification           bool           // send server managed notification
+	CustomMessage              string         // notification message
+	IncludeAnonymousLinks      bool           // include anonymous links into the notification
+	PropagateACL               bool           // propagate permissions to the item's children
+}
+
+// UserSharingResult UpdateDocumentSharingInfo result for a recipient
+type UserSharingResult struct {
+	CurrentRole    int    `json:"CurrentRole"`
+	DisplayName    string `json:"DisplayName"`
+	Email          string `json:"Email"`
+	InvitationLink string `json:"InvitationLink"`
+	IsUserKnown    bool   `json:"IsUserKnown"`
+	Message        string `json:"Message"`
+	Status         bool   `json:"Status"`
+	User           string `json:"User"`
+}
+
+// NewSharing - Sharing struct constructor function
+func NewSharing(client *gosip.SPClient, endpoint string, config *RequestConfig) *Sharing {
+	return &Sharing{
+		client:   client,
+		endpoint: endpoint,
+		config:   config,
+	}
+}
+
+// Sharing gets Sharing API instance object for this Item
+func (item *Item) Sharing() *Sharing {
+	return NewSharing(item.client, item.endpoint, item.config)
+}
+
+// Sharing gets Sharing API instance object for this File
+func (file *File) Sharing() *Sharing {
+	return NewSharing(file.client, fmt.Sprintf("%s/ListItemAllFields", file.endpoint), file.config)
+}
+
+// Sharing gets Sharing API instance object for this Folder
+func (folder *Folder) Sharing() *Sharing {
+	return NewSharing(folder.client, fmt.Sprintf("%s/ListItemAllFields", folder.endpoint), folder.config)
+}
+
+// CreateLink creates or gets existing sharing link of the kind, see SharingLinkKinds
+func (sharing *Sharing) CreateLink(linkKind int, options *SharingLinkOptions) (*SharingLinkInfo, error) {
+	if options == nil {
+		options = &SharingLinkOptions{}
+	}
+	settings := map[string]interface{}{"linkKind": linkKind}
+	if !options.Expiration.IsZero() {
+		settings["expiration"] = options.Expiration.UTC().Format(time.RFC3339)
+	}
+	if options.Password != "" {
+		settings["password"] = options.Password
+	}
+	if linkKind == SharingLinkKinds.Flexible {
+		settings["role"] = options.Role
+		settings["allowAnonymousAccess"] = options.AllowAnonymousAccess
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"request": map[string]interface{}{
+			"createLink": true,
+			"settings":   settings,
+		},
+	})
+
+	client := NewHTTPClient(sharing.client)
+	data, err := client.Post(sharing.endpoint+"/ShareLink", bytes.NewBuffer(body), sharing.config)
+	if err != nil {
+		return nil, err
+	}
+	res := &struct {
+		SharingLinkInfo *SharingLinkInfo `json:"sharingLinkInfo"`
+	}{}
+	if err := parseSharingResp(data, "ShareLink", &res); err != nil {
+		return nil, err
+	}
+	if res.SharingLinkInfo == nil {
+		return nil, fmt.Errorf("no sharing link info in the response")
+	}
+	return res.SharingLinkInfo, nil
+}
+
+// Info gets sharing state with the links and the principals the item is shared with,
+// options limit the number of the returned principals and link members
+func (sharing *Sharing) Info(options *SharingInfoOptions) (*SharingInfo, error) {
+	body, _ := json.Marshal(sharingInfoRequest(options))
+	client := NewHTTPClient(sharing.client)
+	endpoint := sharing.endpoint + "/GetSharingInformation?$expand=permissionsInformation"
+	data, err := client.Post(endpoint, bytes.NewBuffer(body), sharing.config)
+	if err != nil {
+		return nil, err
+	}
+	info := &SharingInfo{}
+	if err := parseSharingResp(data, "GetSharingInformation", &info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// Links gets existing sharing links of the item, options limit the number of the returned link members
+func (sharing *Sharing) Links(options *SharingInfoOptions) ([]*SharingLink, error) {
+	info, err := sharing.Info(options)
+	if err != nil {
+		return nil, err
+	}
+	if info.PermissionsInformation == nil {
+		return []*SharingLink{}, nil
+	}
+	return info.PermissionsInformation.Links, nil
+}
+
+// UnshareLink removes the sharing link, the link kind and share ID are taken from SharingLinkInfo
+func (sharing *Sharing) UnshareLink(linkKind int, shareID string) error {
+	body, _ := json.Marshal(map[string]interface{}{
+		"linkKind": linkKind,
+		"shareId":  shareID,
+	})
+	client := NewHTTPClient(sharing.client)
+	_, err := client.Post(sharing.endpoint+"/UnshareLink", bytes.NewBuffer(body), sharing.config)
+	return err
+}
+
+// ShareObject invites users to the item or adds them to a group granting the access
+func (sharing *Sharing) ShareObject(info *ShareObjectInfo) (*SharingResult, error) {
+	objectURL, err := sharing.objectURL()
+	if err != nil {
+		return nil, err
+	}
+	var peoplePicker []map[string]string
+	for _, loginName := range info.LoginNames {
+		peoplePicker = append(peoplePicker, map[string]string{"Key": loginName})
+	}
+	peoplePickerInput, _ := json.Marshal(peoplePicker)
+	roleValue := fmt.Sprintf("role:%d", info.RoleDefID)
+	if info.GroupID != 0 {
+		roleValue = fmt.Sprintf("group:%d", info.GroupID)
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"url":                         objectURL,
+		"peoplePickerInput":           string(peoplePickerInput),
+		"roleValue":                   roleValue,
+		"groupId":                     info.GroupID,
+		"propagateAcl":                info.PropagateACL,
+		"sendEmail":                   info.SendEmail,
+		"includeAnonymousLinkInEmail": info.IncludeAnonymousLink,
+		"emailSubject":                info.EmailSubject,
+		"emailBody":                   info.EmailBody,
+		"useSimplifiedRoles":          true,
+	})
+	return sharing.sharingResult("SP.Web.ShareObject", body)
+}
+
+// Unshare removes all sharing of the item: links and invitations
+func (sharing *Sharing) Unshare() (*SharingResult, error) {
+	objectURL, err := sharing.objectURL()
+	if err != nil {
+		return nil, err
+	}
+	body, _ := json.Marshal(map[string]interface{}{"url": objectURL})
+	return sharing.sharingResult("SP.Web.UnshareObject", body)
+}
+
+// UpdateDocumentSharingInfo shares the document with the recipients in the roles
+func (sharing *Sharing) UpdateDocumentSharingInfo(info *DocumentSharingInfo) ([]*UserSharingResult, error) {
+	objectURL, err := sharing.objectURL()
+	if err != nil {
+		return nil, err
+	}
+	var assignments []map[string]interface{}
+	for userID, role := range info.Recipients {
+		assignments = append(assignments, map[string]interface{}{
+			"__metadata": map[string]string{"type": "SP.Sharing.UserRoleAssignment"},
+			"Role":       role,
+			"UserId":     userID,
+		})
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"resourceAddress":                     objectURL,
+		"userRoleAssignments":                 assignments,
+		"validateExistingPermissions":         info.ValidateExistingPermission,
+		"additiveMode":                        info.AdditiveMode,
+		"sendServerManagedNotification":       info.SendNotification,
+		"customMessage":                       info.CustomMessage,
+		"includeAnonymousLinksInNotification": info.IncludeAnonymousLinks,
+		"propagateAcl":                        info.PropagateACL,
+	})
+
+	client := NewHTTPClient(sharing.client)
+	endpoint := getPriorEndpoint(sharing.endpoint, "/_api") + "/_api/SP.Sharing.DocumentSharingManager.UpdateDocumentSharingInfo"
+	data, err := client.Post(endpoint, bytes.NewBuffer(body), sharing.config)
+	if err != nil {
+		return nil, err
+	}
+	var results []*UserSharingResult
+	if err := parseSharingResp(data, "UpdateDocumentSharingInfo", &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// sharingResult calls SP.Web static sharing method and checks the result status
+func (sharing *Sharing) sharingResult(method string, body []byte) (*SharingResult, error) {
+	client := NewHTTPClient(sharing.client)
+	endpoint := getPriorEndpoint(sharing.endpoint, "/_api") + "/_api/" + method
+	data, err := client.Post(endpoint, bytes.NewBuffer(body), sharing.config)
+	if err != nil {
+		return nil, err
+	}
+	res := &SharingResult{}
+	if err := parseSharingResp(data, strings.TrimPrefix(method, "SP.Web."), &res); err != nil {
+		return nil, err
+	}
+	if res.StatusCode < 0 {
+		return res, fmt.Errorf("sharing failed with status %d: %s", res.StatusCode, res.ErrorMessage)
+	}
+	return res, nil
+}
+
+// sharingInfoRequest gets GetSharingInformation request payload, the limits are 100 by default
+func sharingInfoRequest(options *SharingInfoOptions) map[string]interface{} {
+	if options == nil {
+		options = &SharingInfoOptions{}
+	}
+	maxPrincipals := options.MaxPrincipals
+	if maxPrincipals == 0 {
+		maxPrincipals = 100
+	}
+	maxLinkMembers := options.MaxLinkMembers
+	if maxLinkMembers == 0 {
+		maxLinkMembers = 100
+	}
+	return map[string]interface{}{
+		"request": map[string]interface{}{
+			"maxPrincipalsToReturn":  maxPrincipals,
+			"maxLinkMembersToReturn": maxLinkMembers,
+		},
+	}
+}
+
+// objectURL gets absolute URL of the item
+func (sharing *Sharing) objectURL() (string, error) {
+	data, err := NewItem(sharing.client, sharing.endpoint, sharing.config).Select("EncodedAbsUrl").Get()
+	if err != nil {
+		return "", err
+	}
+	res := &struct {
+		EncodedAbsURL string `json:"EncodedAbsUrl"`
+	}{}
+	if err := json.Unmarshal(data.Normalized(), &res); err != nil {
+		return "", err
+	}
+	return res.EncodedAbsURL, nil
+}
+
+// parseSharingResp unmarshals sharing method response, verbose responses are wrapped into the method name property
+// and collections are either `results` or `value` arrays
+func parseSharingResp(data []byte, method string, v interface{}) error {
+	data = NormalizeODataItem(data)
+	wrapped := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &wrapped); err == nil {
+		if d, ok := wrapped[method]; ok {
+			data = d
+			wrapped = map[string]json.RawMessage{}
+			_ = json.Unmarshal(data, &wrapped)
+		}
+		for _, key := range []string{"results", "value"} {
+			if d, ok := wrapped[key]; ok {
+				data = d
+				break
+			}
+		}
+	}
+	if len(data) > 0 && data[0] == '[' {
+		collection := []map[string]interface{}{}
+		if err := json.Unmarshal(data, &collection); err == nil {
+			for i, item := range collection {
+				collection[i] = normalizeMultiLookupsMap(item)
+			}
+			data, _ = json.Marshal(collection)
+		}
+	} else {
+		data = normalizeMultiLookups(data)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("unable to parse the response: %w", err)
+	}
+	return nil
+}
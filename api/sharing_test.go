@@ -0,0 +1,119 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSharing(t *testing.T) {
+
+	t.Run("SharingInfoRequest", func(t *testing.T) {
+		limits := func(options *SharingInfoOptions) (interface{}, interface{}) {
+			req := sharingInfoRequest(options)["request"].(map[string]interface{})
+			return req["maxPrincipalsToReturn"], req["maxLinkMembersToReturn"]
+		}
+		if p, m := limits(nil); p != 100 || m != 100 {
+			t.Errorf("unexpected default limits: %v, %v", p, m)
+		}
+		if p, m := limits(&SharingInfoOptions{MaxPrincipals: 500, MaxLinkMembers: 10}); p != 500 || m != 10 {
+			t.Errorf("unexpected limits: %v, %v", p, m)
+		}
+	})
+
+	t.Run("ParseSharingResp", func(t *testing.T) {
+		verbose := []byte(`{"d":{"ShareLink":{"__metadata":{"type":"SP.ShareLinkResponse"},"sharingLinkInfo":{"LinkKind":2,"ShareId":"abc","Url":"https://contoso/link"}}}}`)
+		nometadata := []byte(`{"sharingLinkInfo":{"LinkKind":2,"ShareId":"abc","Url":"https://contoso/link"}}`)
+		for _, data := range [][]byte{verbose, nometadata} {
+			res := &struct {
+				SharingLinkInfo *SharingLinkInfo `json:"sharingLinkInfo"`
+			}{}
+			if err := parseSharingResp(data, "ShareLink", &res); err != nil {
+				t.Fatal(err)
+			}
+			if res.SharingLinkInfo == nil || res.SharingLinkInfo.ShareID != "abc" || res.SharingLinkInfo.LinkKind != SharingLinkKinds.OrganizationView {
+				t.Errorf("unexpected link info: %+v", res.SharingLinkInfo)
+			}
+		}
+
+		verboseCol := []byte(`{"d":{"UpdateDocumentSharingInfo":{"__metadata":{"type":"Collection(SP.Sharing.UserSharingResult)"},"results":[{"Email":"user@contoso.com","Status":true}]}}}`)
+		nometadataCol := []byte(`{"value":[{"Email":"user@contoso.com","Status":true}]}`)
+		for _, data := range [][]byte{verboseCol, nometadataCol} {
+			var res []*UserSharingResult
+			if err := parseSharingResp(data, "UpdateDocumentSharingInfo", &res); err != nil {
+				t.Fatal(err)
+			}
+			if len(res) != 1 || res[0].Email != "user@contoso.com" || !res[0].Status {
+				t.Errorf("unexpected results: %+v", res)
+			}
+		}
+
+		info := &SharingInfo{}
+		data := []byte(`{"d":{"GetSharingInformation":{"permissionsInformation":{"links":{"results":[{"isInherited":false,"linkDetails":{"ShareId":"abc"},"linkMembers":{"results":[]}}]}}}}}`)
+		if err := parseSharingResp(data, "GetSharingInformation", &info); err != nil {
+			t.Fatal(err)
+		}
+		if info.PermissionsInformation == nil || len(info.PermissionsInformation.Links) != 1 || info.PermissionsInformation.Links[0].Details.ShareID != "abc" {
+			t.Errorf("unexpected sharing info: %+v", info.PermissionsInformation)
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	newFolderName := uuid.New().String()
+	rootFolderURI := getRelativeURL(spClient.AuthCnfg.GetSiteURL()) + "/Shared%20Documents"
+	newFolderURI := rootFolderURI + "/" + newFolderName
+	if _, err := web.GetFolder(rootFolderURI).Folders().Add(newFolderName); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := web.GetFolder(newFolderURI).Files().Add("shared.txt", []byte("shared"), true); err != nil {
+		t.Fatal(err)
+	}
+	file := web.GetFile(newFolderURI + "/shared.txt")
+
+	var link *SharingLinkInfo
+
+	t.Run("CreateLink", func(t *testing.T) {
+		l, err := file.Sharing().CreateLink(SharingLinkKinds.OrganizationView, nil)
+		if err != nil {
+			t.Skipf("sharing links are not available: %s", err)
+		}
+		if l.URL == "" {
+			t.Error("link URL should not be empty")
+		}
+		link = l
+	})
+
+	t.Run("Links", func(t *testing.T) {
+		if link == nil {
+			t.Skip("no link was created")
+		}
+		links, err := file.Sharing().Links(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, l := range links {
+			if l.Details != nil && l.Details.ShareID == link.ShareID {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("link %s was not found", link.ShareID)
+		}
+	})
+
+	t.Run("UnshareLink", func(t *testing.T) {
+		if link == nil {
+			t.Skip("no link was created")
+		}
+		if err := file.Sharing().UnshareLink(link.LinkKind, link.ShareID); err != nil {
+			t.Error(err)
+		}
+	})
+
+	if err := web.GetFolder(newFolderURI).Delete(); err != nil {
+		t.Error(err)
+	}
+}
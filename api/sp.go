@@ -71,6 +71,15 @@ func (sp *SP) Profiles() *Profiles {
 	)
 }
 
+// PeoplePicker getter
+func (sp *SP) PeoplePicker() *PeoplePicker {
+	return NewPeoplePicker(
+		sp.client,
+		fmt.Sprintf("%s/_api/SP.UI.ApplicationPages.ClientPeoplePickerWebServiceInterface", sp.ToURL()),
+		sp.config,
+	)
+}
+
 // Taxonomy getter
 func (sp *SP) Taxonomy() *Taxonomy {
 	return NewTaxonomy(sp.client, sp.ToURL(), sp.config)
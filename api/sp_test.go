@@ -39,6 +39,13 @@ func TestSP(t *testing.T) {
 		}
 	})
 
+	t.Run("PeoplePicker", func(t *testing.T) {
+		sp := NewSP(spClient)
+		if sp.PeoplePicker() == nil {
+			t.Errorf("failed to get PeoplePicker object")
+		}
+	})
+
 	t.Run("Search", func(t *testing.T) {
 		sp := NewSP(spClient)
 		if sp.Search() == nil {
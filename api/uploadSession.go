@@ -0,0 +1,125 @@
+package api
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// UploadSession describes a chunked upload state required to resume the upload
+type UploadSession struct {
+	UploadID    string `json:"uploadId"`    // chunked upload ID
+	FileURL     string `json:"fileUrl"`     // uploaded file server relative URL
+	Offset      int    `json:"offset"`      // number of bytes confirmed by the server
+	Checksum    string `json:"checksum"`    // hex encoded SHA-256 of the uploaded content
+	ChunkSize   int    `json:"chunkSize"`   // chunk size used for the upload
+	BlockNumber int    `json:"blockNumber"` // number of uploaded chunks
+}
+
+// UploadSessionStore persists chunked upload sessions, sessions are identified by the file server relative URL
+type UploadSessionStore interface {
+	Save(session *UploadSession) error           // saves or replaces the session
+	Load(fileURL string) (*UploadSession, error) // loads the session, returns nil when there is no session for the file
+	Delete(fileURL string) error                 // deletes the session, should not fail when there is no session
+}
+
+// MemoryUploadSessionStore is an in-memory UploadSessionStore, sessions survive failed uploads but not the process restart
+type MemoryUploadSessionStore struct {
+	sessions map[string]UploadSession
+	mu       sync.Mutex
+}
+
+// NewMemoryUploadSessionStore - MemoryUploadSessionStore constructor function
+func NewMemoryUploadSessionStore() *MemoryUploadSessionStore {
+	return &MemoryUploadSessionStore{sessions: map[string]UploadSession{}}
+}
+
+// Save saves or replaces the session
+func (store *MemoryUploadSessionStore) Save(session *UploadSession) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.sessions[strings.ToLower(session.FileURL)] = *session
+	return nil
+}
+
+// Load loads the session, returns nil when there is no session for the file
+func (store *MemoryUploadSessionStore) Load(fileURL string) (*UploadSession, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	session, ok := store.sessions[strings.ToLower(fileURL)]
+	if !ok {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+// Delete deletes the session
+func (store *MemoryUploadSessionStore) Delete(fileURL string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	delete(store.sessions, strings.ToLower(fileURL))
+	return nil
+}
+
+// FileUploadSessionStore is an UploadSessionStore keeping sessions as JSON files in a local folder
+type FileUploadSessionStore struct {
+	dir string
+}
+
+// NewFileUploadSessionStore - FileUploadSessionStore constructor function, the folder is created when missing
+func NewFileUploadSessionStore(dir string) (*FileUploadSessionStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create sessions folder: %w", err)
+	}
+	return &FileUploadSessionStore{dir: dir}, nil
+}
+
+// Save saves or replaces the session
+func (store *FileUploadSessionStore) Save(session *UploadSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	// writing to a temp file first not to corrupt the session on an interrupted write
+	tmpPath := store.sessionPath(session.FileURL) + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, store.sessionPath(session.FileURL))
+}
+
+// Load loads the session, returns nil when there is no session for the file
+func (store *FileUploadSessionStore) Load(fileURL string) (*UploadSession, error) {
+	data, err := ioutil.ReadFile(store.sessionPath(fileURL))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	session := &UploadSession{}
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("unable to parse upload session: %w", err)
+	}
+	return session, nil
+}
+
+// Delete deletes the session
+func (store *FileUploadSessionStore) Delete(fileURL string) error {
+	if err := os.Remove(store.sessionPath(fileURL)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// sessionPath gets session file path by the file URL
+func (store *FileUploadSessionStore) sessionPath(fileURL string) string {
+	hash := sha1.Sum([]byte(strings.ToLower(fileURL)))
+	return filepath.Join(store.dir, hex.EncodeToString(hash[:])+".json")
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUploadSession(t *testing.T) {
+
+	t.Run("Stores", func(t *testing.T) {
+		fileStore, err := NewFileUploadSessionStore(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, store := range []UploadSessionStore{NewMemoryUploadSessionStore(), fileStore} {
+			session := &UploadSession{UploadID: "id", FileURL: "/sites/s/Docs/File.txt", Offset: 10, Checksum: "hash"}
+			if err := store.Save(session); err != nil {
+				t.Error(err)
+			}
+			loaded, err := store.Load("/sites/s/docs/file.txt")
+			if err != nil {
+				t.Error(err)
+			}
+			if loaded == nil || *loaded != *session {
+				t.Errorf("incorrect loaded session: %+v", loaded)
+			}
+			if err := store.Delete(session.FileURL); err != nil {
+				t.Error(err)
+			}
+			if err := store.Delete(session.FileURL); err != nil {
+				t.Error(err)
+			}
+			if loaded, _ := store.Load(session.FileURL); loaded != nil {
+				t.Error("session should be deleted")
+			}
+		}
+	})
+
+	t.Run("ReadChunk", func(t *testing.T) {
+		slot := make([]byte, 4)
+		stream := strings.NewReader("123456")
+		if n, err := readChunk(stream, slot); n != 4 || err != nil {
+			t.Errorf("incorrect full chunk read: %d, %v", n, err)
+		}
+		if n, err := readChunk(stream, slot); n != 2 || err != nil {
+			t.Errorf("incorrect short chunk read: %d, %v", n, err)
+		}
+		if n, err := readChunk(stream, slot); n != 0 || err != nil {
+			t.Errorf("incorrect empty chunk read: %d, %v", n, err)
+		}
+	})
+
+	t.Run("RetryChunk", func(t *testing.T) {
+		attempts := 0
+		err := retryChunk(nil, 2, nil, func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("transient")
+			}
+			return nil
+		})
+		if err != nil || attempts != 3 {
+			t.Errorf("incorrect retries: %d, %v", attempts, err)
+		}
+		attempts = 0
+		if err := retryChunk(nil, -1, nil, func() error { attempts++; return errors.New("failure") }); err == nil || attempts != 1 {
+			t.Errorf("retries should be disabled: %d, %v", attempts, err)
+		}
+	})
+
+	t.Run("ChunkPipeline", func(t *testing.T) {
+		reader, writer := io.Pipe()
+		go func() {
+			for i := 0; i < 7; i++ {
+				_, _ = writer.Write([]byte("12")) // short writes, each read gets less than a chunk
+			}
+			_ = writer.Close()
+		}()
+		pipeline := newChunkPipeline(reader, getAddChunkedOptions(&AddChunkedOptions{ChunkSize: 5}, 0))
+		defer pipeline.close()
+		var sizes []int
+		for {
+			chunk, err := pipeline.next(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sizes = append(sizes, len(chunk.data))
+			if chunk.last {
+				break
+			}
+			pipeline.release(chunk)
+		}
+		if fmt.Sprintf("%v", sizes) != "[5 5 4]" {
+			t.Errorf("incorrect chunks sizes: %v", sizes)
+		}
+	})
+
+	t.Run("AdaptiveChunkSize", func(t *testing.T) {
+		options := getAddChunkedOptions(&AddChunkedOptions{ChunkSize: 100, TargetChunkDuration: time.Second}, 0)
+		pipeline := newChunkPipeline(strings.NewReader(""), options)
+		defer pipeline.close()
+		pipeline.adapt(100, 100*time.Millisecond) // fast upload, grows no more than twice
+		if size := atomic.LoadInt64(&pipeline.chunkSize); size != 200 {
+			t.Errorf("incorrect grown chunk size: %d", size)
+		}
+		pipeline.adapt(200, 10*time.Second) // slow upload, shrinks no more than twice
+		if size := atomic.LoadInt64(&pipeline.chunkSize); size != 100 {
+			t.Errorf("incorrect shrunk chunk size: %d", size)
+		}
+		pipeline.adapt(100, 100*time.Second) // limited by MinChunkSize
+		if size := atomic.LoadInt64(&pipeline.chunkSize); size != 50 {
+			t.Errorf("incorrect limited chunk size: %d", size)
+		}
+	})
+
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/pnocera/gosip"
 )
 
-//go:generate ggen -ent User -conf -mods Select,Expand -helpers Data,Normalized
+//go:generate ggen -ent User -conf -etag -mods Select,Expand -helpers Data,Normalized
 
 // User represents SharePoint Site User API queryable object struct
 // Always use NewUser constructor instead of &User{}
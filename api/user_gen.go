@@ -1,4 +1,4 @@
-// Code generated by `ggen -ent User -conf -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
+// Code generated by `ggen -ent User -conf -etag -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
 
 package api
 
@@ -38,3 +38,18 @@ func (userResp *UserResp) Data() *UserInfo {
 func (userResp *UserResp) Normalized() []byte {
 	return NormalizeODataItem(*userResp)
 }
+
+/* Optimistic concurrency helpers */
+
+// IfMatch gets a copy of the entity with the expected ETag for Update and Delete requests,
+// the requests fail with *PreconditionFailedError when the entity was modified since the ETag was received
+func (user *User) IfMatch(etag string) *User {
+	scoped := *user
+	scoped.config = withIfMatch(user.config, etag)
+	return &scoped
+}
+
+// ETag gets entity ETag from the response metadata
+func (userResp *UserResp) ETag() string {
+	return getODataETag(*userResp)
+}
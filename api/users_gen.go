@@ -55,3 +55,36 @@ func (usersResp *UsersResp) Normalized() []byte {
 	normalized, _ := NormalizeODataCollection(*usersResp)
 	return normalized
 }
+
+/* Pagination helpers */
+
+// UsersPager - Users collection pages iterator
+type UsersPager struct {
+	*Pager
+}
+
+// Pager gets Users collection pages iterator, the iteration follows OData next page links
+func (users *Users) Pager() *UsersPager {
+	return &UsersPager{NewPager(users.client, users.ToURL(), users.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *UsersPager) Prefetch(enabled bool) *UsersPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *UsersPager) Page() UsersResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (usersResp *UsersResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*usersResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (usersResp *UsersResp) HasNextPage() bool {
+	return usersResp.NextPageURL() != ""
+}
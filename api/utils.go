@@ -95,6 +95,57 @@ func patchConfigHeaders(config *RequestConfig, headers map[string]string) *Reque
 	return conf
 }
 
+// withIfMatch gets a copy of config with If-Match header, empty ETag removes the header
+func withIfMatch(config *RequestConfig, etag string) *RequestConfig {
+	conf := &RequestConfig{Headers: map[string]string{}}
+	if config != nil {
+		conf.Context = config.Context
+		for k, v := range config.Headers {
+			conf.Headers[k] = v
+		}
+	}
+	if etag == "" {
+		delete(conf.Headers, "If-Match")
+		return conf
+	}
+	conf.Headers["If-Match"] = etag
+	return conf
+}
+
+// getODataETag gets entity ETag from verbose `__metadata.etag` or minimal metadata `odata.etag` response properties,
+// falls back to entity `ETag` property
+func getODataETag(payload []byte) string {
+	v := &struct {
+		D *struct {
+			Metadata struct {
+				ETag string `json:"etag"`
+			} `json:"__metadata"`
+			ETag string `json:"ETag"`
+		} `json:"d"`
+		Metadata struct {
+			ETag string `json:"etag"`
+		} `json:"__metadata"`
+		ODataETag  string `json:"odata.etag"`
+		ODataETag4 string `json:"@odata.etag"`
+		ETag       string `json:"ETag"`
+	}{}
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return ""
+	}
+	if v.D != nil {
+		if v.D.Metadata.ETag != "" {
+			return v.D.Metadata.ETag
+		}
+		return v.D.ETag
+	}
+	for _, etag := range []string{v.Metadata.ETag, v.ODataETag, v.ODataETag4, v.ETag} {
+		if etag != "" {
+			return etag
+		}
+	}
+	return ""
+}
+
 // getRelativeURL out of an absolute one
 func getRelativeURL(absURL string) string {
 	u, _ := url.Parse(absURL)
@@ -112,6 +163,28 @@ func checkGetRelativeURL(relativeURI string, ctxURL string) string {
 	return relativeURI
 }
 
+// escapeResourcePath escapes a decoded path used in OData `'...'` URL parameters of ResourcePath methods,
+// e.g. `GetFileByServerRelativePath(decodedUrl='...')`. Single quotes are doubled and characters
+// which would otherwise end the URL path or be decoded by the server (`%`, `#`, `?`) are percent-encoded.
+func escapeResourcePath(decodedURL string) string {
+	return resourcePathReplacer.Replace(decodedURL)
+}
+
+// escapeODataString escapes a value used as OData string literal in `'...'` URL parameters,
+// e.g. `GetByName('...')`. Single quotes are doubled and characters which would otherwise end
+// the URL path or be decoded by the server (`%`, `#`, `?`) are percent-encoded, e.g. in claims login names.
+func escapeODataString(value string) string {
+	return resourcePathReplacer.Replace(value)
+}
+
+// resourcePathReplacer escapes OData string literals and ResourcePath values
+var resourcePathReplacer = strings.NewReplacer(
+	"%", "%25",
+	"#", "%23",
+	"?", "%3F",
+	"'", "''",
+)
+
 // getPriorEndpoint gets endpoint before the provided part ignoring case
 func getPriorEndpoint(endpoint string, part string) string {
 	strLen := len(strings.Split(strings.ToLower(endpoint), strings.ToLower(part))[0])
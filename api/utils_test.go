@@ -337,4 +337,66 @@ func TestUtils(t *testing.T) {
 		}
 	})
 
+	t.Run("withIfMatch", func(t *testing.T) {
+		base := &RequestConfig{Headers: map[string]string{"Accept": "application/json"}}
+		conf := withIfMatch(base, `"2"`)
+		if conf.Headers["If-Match"] != `"2"` || conf.Headers["Accept"] != "application/json" {
+			t.Error("incorrect headers")
+		}
+		if _, ok := base.Headers["If-Match"]; ok {
+			t.Error("source config should not be modified")
+		}
+		if _, ok := withIfMatch(conf, "").Headers["If-Match"]; ok {
+			t.Error("empty ETag should remove the header")
+		}
+	})
+
+	t.Run("getODataETag", func(t *testing.T) {
+		payloads := map[string]string{
+			`{"d":{"__metadata":{"etag":"\"1\""},"Title":"Item"}}`: `"1"`,
+			`{"odata.etag":"\"2\"","Title":"Item"}`:                `"2"`,
+			`{"@odata.etag":"\"3\"","Title":"Item"}`:               `"3"`,
+			`{"d":{"ETag":"\"{GUID},4\""}}`:                        `"{GUID},4"`,
+			`{"Title":"Item"}`:                                     "",
+		}
+		for payload, expected := range payloads {
+			if etag := getODataETag([]byte(payload)); etag != expected {
+				t.Errorf("incorrect ETag of %s, expected %s, got %s", payload, expected, etag)
+			}
+		}
+	})
+
+}
+
+func TestEscapeResourcePath(t *testing.T) {
+	cases := map[string]string{
+		"/sites/site/Shared Documents/file.txt": "/sites/site/Shared Documents/file.txt",
+		"O'Brien.docx":                          "O''Brien.docx",
+		"100%.txt":                              "100%25.txt",
+		"#1 report.pdf":                         "%231 report.pdf",
+		"what?.txt":                             "what%3F.txt",
+		"%20 is not a space.txt":                "%2520 is not a space.txt",
+		"'quoted' & #tagged% ?":                 "''quoted'' & %23tagged%25 %3F",
+		"Ünïcödé ~!@$^()[]{};,=+.txt":           "Ünïcödé ~!@$^()[]{};,=+.txt",
+	}
+	for name, expected := range cases {
+		if escaped := escapeResourcePath(name); escaped != expected {
+			t.Errorf("incorrect escaping of `%s`, expected `%s`, got `%s`", name, expected, escaped)
+		}
+	}
+}
+
+func TestEscapeODataString(t *testing.T) {
+	cases := map[string]string{
+		"Site Owners":                        "Site Owners",
+		"O'Brien's group":                    "O''Brien''s group",
+		"i:0#.f|membership|user@contoso.com": "i:0%23.f|membership|user@contoso.com",
+		"100% #1 what?":                      "100%25 %231 what%3F",
+		"%20 is not a space":                 "%2520 is not a space",
+	}
+	for value, expected := range cases {
+		if escaped := escapeODataString(value); escaped != expected {
+			t.Errorf("incorrect escaping of `%s`, expected `%s`, got `%s`", value, expected, escaped)
+		}
+	}
 }
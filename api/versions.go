@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VersionInfo - file or list item version payload structure
+type VersionInfo struct {
+	ID               int                    // version ID, e.g. 512 for 1.0
+	Label            string                 // version label, e.g. "1.0"
+	Created          time.Time              // version creation date
+	IsCurrentVersion bool                   // is the version current
+	CheckInComment   string                 // check in comment (file versions only)
+	Size             int                    // version content size in bytes (file versions only)
+	URL              string                 // version content URL (file versions only)
+	Author           *VersionAuthor         // version author, for file versions requires `CreatedBy` expand
+	Values           map[string]interface{} // version fields values (list item versions only)
+}
+
+// VersionAuthor - version author info
+type VersionAuthor struct {
+	ID        int
+	Title     string
+	LoginName string
+	Email     string
+}
+
+// parseVersionInfo parses file version (SP.FileVersion) or item version (SP.ListItemVersion) payload
+func parseVersionInfo(payload []byte) *VersionInfo {
+	data := normalizeMultiLookups(NormalizeODataItem(payload))
+	raw := map[string]interface{}{}
+	_ = json.Unmarshal(data, &raw)
+
+	toInt := func(v interface{}) int {
+		switch n := v.(type) {
+		case float64:
+			return int(n)
+		case string:
+			i, _ := strconv.Atoi(n)
+			return i
+		}
+		return 0
+	}
+	toString := func(v interface{}) string {
+		s, _ := v.(string)
+		return s
+	}
+
+	info := &VersionInfo{
+		Label:          toString(raw["VersionLabel"]),
+		CheckInComment: toString(raw["CheckInComment"]),
+		Size:           toInt(raw["Size"]),
+		URL:            toString(raw["Url"]),
+	}
+	info.IsCurrentVersion, _ = raw["IsCurrentVersion"].(bool)
+	info.Created, _ = time.Parse(time.RFC3339, toString(raw["Created"]))
+
+	// File version
+	if id, ok := raw["ID"]; ok {
+		info.ID = toInt(id)
+		if author, ok := raw["CreatedBy"].(map[string]interface{}); ok {
+			info.Author = &VersionAuthor{
+				ID:        toInt(author["Id"]),
+				Title:     toString(author["Title"]),
+				LoginName: toString(author["LoginName"]),
+				Email:     toString(author["Email"]),
+			}
+		}
+		return info
+	}
+
+	// List item version
+	info.ID = toInt(raw["VersionId"])
+	if author, ok := raw["Editor"].(map[string]interface{}); ok {
+		info.Author = &VersionAuthor{
+			ID:    toInt(author["LookupId"]),
+			Title: toString(author["LookupValue"]),
+			Email: toString(author["Email"]),
+		}
+	}
+	info.Values = map[string]interface{}{}
+	for key, val := range raw {
+		if key == "__metadata" || strings.HasPrefix(key, "odata.") {
+			continue
+		}
+		info.Values[key] = val
+	}
+	return info
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestVersions(t *testing.T) {
+
+	t.Run("parseVersionInfo/File", func(t *testing.T) {
+		payload := []byte(`{"d":{"CheckInComment":"test","Created":"2020-01-02T08:00:00Z","ID":512,"IsCurrentVersion":false,"Size":"11","Url":"_vti_history/512/Shared Documents/a.txt","VersionLabel":"1.0","CreatedBy":{"Id":7,"Title":"User","LoginName":"i:0#.f|membership|user@contoso.com","Email":"user@contoso.com"}}}`)
+		info := parseVersionInfo(payload)
+		if info.ID != 512 || info.Label != "1.0" || info.Size != 11 || info.CheckInComment != "test" {
+			t.Errorf("incorrect version info: %+v", info)
+		}
+		if info.Author == nil || info.Author.ID != 7 {
+			t.Error("incorrect version author")
+		}
+		if info.Created.Year() != 2020 {
+			t.Error("incorrect version date")
+		}
+	})
+
+	t.Run("parseVersionInfo/Item", func(t *testing.T) {
+		payload := []byte(`{"VersionId":1024,"VersionLabel":"2.0","IsCurrentVersion":true,"Created":"2020-01-02T08:00:00Z","Title":"Item","Editor":{"LookupId":7,"LookupValue":"User","Email":"user@contoso.com"}}`)
+		info := parseVersionInfo(payload)
+		if info.ID != 1024 || info.Label != "2.0" || !info.IsCurrentVersion {
+			t.Errorf("incorrect version info: %+v", info)
+		}
+		if info.Author == nil || info.Author.Title != "User" {
+			t.Error("incorrect version author")
+		}
+		if info.Values["Title"] != "Item" {
+			t.Error("incorrect version values")
+		}
+	})
+
+	t.Run("versionRestorePayload", func(t *testing.T) {
+		fields := []*transferField{
+			{InternalName: "Title", TypeAsString: "Text"},
+			{InternalName: "Lookup", TypeAsString: "Lookup"},
+			{InternalName: "Modified", TypeAsString: "DateTime", ReadOnlyField: true},
+		}
+		values := map[string]interface{}{
+			"Title":    "Item",
+			"Lookup":   map[string]interface{}{"LookupId": 3.0, "LookupValue": "Three"},
+			"Modified": "2020-01-02T08:00:00Z",
+		}
+		payload := versionRestorePayload(fields, values)
+		if payload["Title"] != "Item" || payload["LookupId"] != 3.0 {
+			t.Errorf("incorrect restore payload: %+v", payload)
+		}
+		if _, ok := payload["Modified"]; ok {
+			t.Error("read only field should not be restored")
+		}
+	})
+
+	checkClient(t)
+
+	web := NewSP(spClient).Web()
+	folderURI := getRelativeURL(spClient.AuthCnfg.GetSiteURL()) + "/Shared%20Documents"
+	fileName := uuid.New().String() + ".txt"
+	for _, content := range []string{"version 1", "version 2", "version 3"} {
+		if _, err := web.GetFolder(folderURI).Files().Add(fileName, []byte(content), true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	file := web.GetFolder(folderURI).Files().GetByName(fileName)
+
+	t.Run("FileVersions/Get", func(t *testing.T) {
+		data, err := file.Versions().Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data.Data()) == 0 {
+			t.Skip("versioning is disabled in the library")
+		}
+		if data.Data()[0].Data().Label == "" {
+			t.Error("can't get version label")
+		}
+	})
+
+	t.Run("FileVersions/Download", func(t *testing.T) {
+		data, err := file.Versions().Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data.Data()) == 0 {
+			t.Skip("versioning is disabled in the library")
+		}
+		version, err := file.Versions().GetByLabel(data.Data()[0].Data().Label)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := version.Download()
+		if err != nil {
+			t.Error(err)
+		}
+		if !bytes.Equal(content, []byte("version 1")) {
+			t.Errorf("unexpected version content: %s", content)
+		}
+	})
+
+	t.Run("ItemVersions/Get", func(t *testing.T) {
+		item, err := file.GetItem()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := item.Versions().Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range data.Data() {
+			if v.Data().IsCurrentVersion && len(v.Data().Values) == 0 {
+				t.Error("can't get version values")
+			}
+		}
+	})
+
+	t.Run("FileVersions/RestoreByLabel", func(t *testing.T) {
+		data, err := file.Versions().Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data.Data()) == 0 {
+			t.Skip("versioning is disabled in the library")
+		}
+		if err := file.Versions().RestoreByLabel(data.Data()[0].Data().Label); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("FileVersions/DeleteAll", func(t *testing.T) {
+		if err := file.Versions().DeleteAll(); err != nil {
+			t.Error(err)
+		}
+		data, err := file.Versions().Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data.Data()) != 0 {
+			t.Error("versions were not deleted")
+		}
+	})
+
+	if err := file.Delete(); err != nil && !strings.Contains(err.Error(), "404") {
+		t.Error(err)
+	}
+
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/pnocera/gosip"
 )
 
-//go:generate ggen -ent View -conf -mods Select,Expand -helpers Data,Normalized
+//go:generate ggen -ent View -conf -etag -mods Select,Expand -helpers Data,Normalized
 
 // View represents SharePoint List View API queryable object struct
 // Always use NewView constructor instead of &View{}
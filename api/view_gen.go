@@ -1,4 +1,4 @@
-// Code generated by `ggen -ent View -conf -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
+// Code generated by `ggen -ent View -conf -etag -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
 
 package api
 
@@ -38,3 +38,18 @@ func (viewResp *ViewResp) Data() *ViewInfo {
 func (viewResp *ViewResp) Normalized() []byte {
 	return NormalizeODataItem(*viewResp)
 }
+
+/* Optimistic concurrency helpers */
+
+// IfMatch gets a copy of the entity with the expected ETag for Update and Delete requests,
+// the requests fail with *PreconditionFailedError when the entity was modified since the ETag was received
+func (view *View) IfMatch(etag string) *View {
+	scoped := *view
+	scoped.config = withIfMatch(view.config, etag)
+	return &scoped
+}
+
+// ETag gets entity ETag from the response metadata
+func (viewResp *ViewResp) ETag() string {
+	return getODataETag(*viewResp)
+}
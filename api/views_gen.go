@@ -55,3 +55,36 @@ func (viewsResp *ViewsResp) Normalized() []byte {
 	normalized, _ := NormalizeODataCollection(*viewsResp)
 	return normalized
 }
+
+/* Pagination helpers */
+
+// ViewsPager - Views collection pages iterator
+type ViewsPager struct {
+	*Pager
+}
+
+// Pager gets Views collection pages iterator, the iteration follows OData next page links
+func (views *Views) Pager() *ViewsPager {
+	return &ViewsPager{NewPager(views.client, views.ToURL(), views.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *ViewsPager) Prefetch(enabled bool) *ViewsPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *ViewsPager) Page() ViewsResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (viewsResp *ViewsResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*viewsResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (viewsResp *ViewsResp) HasNextPage() bool {
+	return viewsResp.NextPageURL() != ""
+}
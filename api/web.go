@@ -9,7 +9,7 @@ import (
 	"github.com/pnocera/gosip"
 )
 
-//go:generate ggen -ent Web -conf -mods Select,Expand -helpers Data,Normalized
+//go:generate ggen -ent Web -conf -etag -mods Select,Expand -helpers Data,Normalized
 
 // Web represents SharePoint Web API queryable object struct
 // Always use NewWeb constructor instead of &Web{}
@@ -362,6 +362,16 @@ func (web *Web) Roles() *Roles {
 	return NewRoles(web.client, web.endpoint, web.config)
 }
 
+// GetUserEffectivePermissions gets effective permissions of the user on this Web
+func (web *Web) GetUserEffectivePermissions(loginName string) (*BasePermissions, error) {
+	return getUserEffectivePermissions(web.client, web.endpoint, loginName, web.config)
+}
+
+// EffectiveBasePermissions gets effective permissions of the current user on this Web
+func (web *Web) EffectiveBasePermissions() (*BasePermissions, error) {
+	return getEffectiveBasePermissions(web.client, web.endpoint, web.config)
+}
+
 // RoleDefinitions gets RoleDefinitions API instance queryable collection for this Web
 func (web *Web) RoleDefinitions() *RoleDefinitions {
 	return NewRoleDefinitions(
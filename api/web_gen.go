@@ -1,4 +1,4 @@
-// Code generated by `ggen -ent Web -conf -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
+// Code generated by `ggen -ent Web -conf -etag -mods Select,Expand -helpers Data,Normalized`; DO NOT EDIT.
 
 package api
 
@@ -38,3 +38,18 @@ func (webResp *WebResp) Data() *WebInfo {
 func (webResp *WebResp) Normalized() []byte {
 	return NormalizeODataItem(*webResp)
 }
+
+/* Optimistic concurrency helpers */
+
+// IfMatch gets a copy of the entity with the expected ETag for Update and Delete requests,
+// the requests fail with *PreconditionFailedError when the entity was modified since the ETag was received
+func (web *Web) IfMatch(etag string) *Web {
+	scoped := *web
+	scoped.config = withIfMatch(web.config, etag)
+	return &scoped
+}
+
+// ETag gets entity ETag from the response metadata
+func (webResp *WebResp) ETag() string {
+	return getODataETag(*webResp)
+}
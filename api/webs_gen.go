@@ -55,3 +55,36 @@ func (websResp *WebsResp) Normalized() []byte {
 	normalized, _ := NormalizeODataCollection(*websResp)
 	return normalized
 }
+
+/* Pagination helpers */
+
+// WebsPager - Webs collection pages iterator
+type WebsPager struct {
+	*Pager
+}
+
+// Pager gets Webs collection pages iterator, the iteration follows OData next page links
+func (webs *Webs) Pager() *WebsPager {
+	return &WebsPager{NewPager(webs.client, webs.ToURL(), webs.config)}
+}
+
+// Prefetch enables loading the next page in background while the current page is processed
+func (pager *WebsPager) Prefetch(enabled bool) *WebsPager {
+	pager.Pager.Prefetch(enabled)
+	return pager
+}
+
+// Page gets current page response
+func (pager *WebsPager) Page() WebsResp {
+	return pager.Pager.Page()
+}
+
+// NextPageURL gets next page OData collection
+func (websResp *WebsResp) NextPageURL() string {
+	return getODataCollectionNextPageURL(*websResp)
+}
+
+// HasNextPage returns is true if next page exists
+func (websResp *WebsResp) HasNextPage() bool {
+	return websResp.NextPageURL() != ""
+}
@@ -16,6 +16,7 @@ type apiGenCnfg struct {
 	IsCollection bool
 	Modificators []string
 	Helpers      []string
+	ETag         bool
 }
 
 func main() {
@@ -25,6 +26,7 @@ func main() {
 	coll := flag.Bool("coll", false, "Is collection entity")
 	mods := flag.String("mods", "", "Modifiers comma separated list")
 	helpers := flag.String("helpers", "", "Helpers comma separated list")
+	etag := flag.Bool("etag", false, "Has IfMatch() method and ETag() response helper")
 	flag.Parse()
 
 	if *ent == "" {
@@ -49,6 +51,7 @@ func main() {
 		IsCollection: *coll,
 		Modificators: m,
 		Helpers:      h,
+		ETag:         *etag,
 	})
 }
 
@@ -116,6 +119,14 @@ func generate(c *apiGenCnfg) error {
 		code += helpersGen(c)
 	}
 
+	if c.IsCollection && c.Item != "" && hasHelper(c, "Data") {
+		code += paginationGen(c)
+	}
+
+	if c.ETag {
+		code += etagGen(c)
+	}
+
 	fmt.Printf("Generated %s (%d bytes)\n", filepath.Join("./", genFileName), len([]byte(code)))
 
 	err := ioutil.WriteFile(filepath.Join("./", genFileName), []byte(code), 0644)
@@ -263,52 +274,74 @@ func helpersGen(c *apiGenCnfg) string {
 	return code
 }
 
-// func paginationGen(entity string) string {
-// 	Ent := entity
-// 	ent := instanceOf(Ent)
-// 	return `
-// 		/* Pagination helpers */
-
-// 		// ` + Ent + `Page - paged items
-// 		type ` + Ent + `Page struct {
-// 			Items       ` + Ent + `Resp
-// 			HasNextPage func() bool
-// 			GetNextPage func() (*` + Ent + `Page, error)
-// 		}
-
-// 		// GetPaged gets Paged Items collection
-// 		func (` + ent + ` *` + Ent + `) GetPaged() (*` + Ent + `Page, error) {
-// 			data, err := ` + ent + `.Get()
-// 			if err != nil {
-// 				return nil, err
-// 			}
-// 			res := &` + Ent + `Page{
-// 				Items: data,
-// 				HasNextPage: func() bool {
-// 					return data.HasNextPage()
-// 				},
-// 				GetNextPage: func() (*` + Ent + `Page, error) {
-// 					nextURL := data.NextPageURL()
-// 					if nextURL == "" {
-// 						return nil, fmt.Errorf("unable to get next page")
-// 					}
-// 					return New` + Ent + `(` + ent + `.client, nextURL, ` + ent + `.config).GetPaged()
-// 				},
-// 			}
-// 			return res, nil
-// 		}
-
-// 		// NextPageURL gets next page OData collection
-// 		func (` + ent + `Resp *` + Ent + `Resp) NextPageURL() string {
-// 			return getODataCollectionNextPageURL(*` + ent + `Resp)
-// 		}
-
-// 		// HasNextPage returns is true if next page exists
-// 		func (` + ent + `Resp *` + Ent + `Resp) HasNextPage() bool {
-// 			return ` + ent + `Resp.NextPageURL() != ""
-// 		}
-// 	`
-// }
+func paginationGen(c *apiGenCnfg) string {
+	Ent := c.Entity
+	ent := instanceOf(Ent)
+	return `
+		/* Pagination helpers */
+
+		// ` + Ent + `Pager - ` + Ent + ` collection pages iterator
+		type ` + Ent + `Pager struct {
+			*Pager
+		}
+
+		// Pager gets ` + Ent + ` collection pages iterator, the iteration follows OData next page links
+		func (` + ent + ` *` + Ent + `) Pager() *` + Ent + `Pager {
+			return &` + Ent + `Pager{NewPager(` + ent + `.client, ` + ent + `.ToURL(), ` + ent + `.config)}
+		}
+
+		// Prefetch enables loading the next page in background while the current page is processed
+		func (pager *` + Ent + `Pager) Prefetch(enabled bool) *` + Ent + `Pager {
+			pager.Pager.Prefetch(enabled)
+			return pager
+		}
+
+		// Page gets current page response
+		func (pager *` + Ent + `Pager) Page() ` + Ent + `Resp {
+			return pager.Pager.Page()
+		}
+
+		// NextPageURL gets next page OData collection
+		func (` + ent + `Resp *` + Ent + `Resp) NextPageURL() string {
+			return getODataCollectionNextPageURL(*` + ent + `Resp)
+		}
+
+		// HasNextPage returns is true if next page exists
+		func (` + ent + `Resp *` + Ent + `Resp) HasNextPage() bool {
+			return ` + ent + `Resp.NextPageURL() != ""
+		}
+	`
+}
+
+func etagGen(c *apiGenCnfg) string {
+	Ent := c.Entity
+	ent := instanceOf(Ent)
+	return `
+		/* Optimistic concurrency helpers */
+
+		// IfMatch gets a copy of the entity with the expected ETag for Update and Delete requests,
+		// the requests fail with *PreconditionFailedError when the entity was modified since the ETag was received
+		func (` + ent + ` *` + Ent + `) IfMatch(etag string) *` + Ent + ` {
+			scoped := *` + ent + `
+			scoped.config = withIfMatch(` + ent + `.config, etag)
+			return &scoped
+		}
+
+		// ETag gets entity ETag from the response metadata
+		func (` + ent + `Resp *` + Ent + `Resp) ETag() string {
+			return getODataETag(*` + ent + `Resp)
+		}
+	`
+}
+
+func hasHelper(c *apiGenCnfg, helper string) bool {
+	for _, h := range c.Helpers {
+		if h == helper {
+			return true
+		}
+	}
+	return false
+}
 
 func instanceOf(entity string) string {
 	if len(entity) < 4 {